@@ -2,33 +2,129 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io"
 	stdlog "log"
+	"mime"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	fileservice "github.com/ladev74/protos/gen/go/file_service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"fileservice/internal/config"
 	"fileservice/internal/logger"
+	"fileservice/internal/tlsconfig"
 )
 
+// callCredentials attaches the token or the api key and the tenant to every call. Transport security is not required
+// because the demo may talk to a local server without tls.
+type callCredentials struct {
+	token  string
+	apiKey string
+	tenant string
+}
+
+func (c *callCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	md := make(map[string]string)
+
+	switch {
+	case c.apiKey != "":
+		md["x-api-key"] = c.apiKey
+	case c.token != "":
+		md["authorization"] = "Bearer " + c.token
+	}
+
+	if c.tenant != "" {
+		md["x-tenant-id"] = c.tenant
+	}
+
+	return md, nil
+}
+
+func (c *callCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func tlsCredentials(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if caFile != "" {
+		rootCAs, err := tlsconfig.LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 type demo struct {
-	client fileservice.FileServiceClient
-	logger *zap.Logger
+	client       fileservice.FileServiceClient
+	tenantClient fileservice.TenantServiceClient
+	logger       *zap.Logger
 }
 
 func main() {
-	var method, id, imagePath, configPath string
+	var method, id, imagePath, configPath, namePrefix, sortBy, token, apiKey string
+	var caFile, certFile, keyFile, serverName string
+	var grantee, permissions, tenantID, tenantName string
+	var group, attachment bool
+	var maxUses int
+	var ascending, useTLS bool
+	var offset, length int64
+	var version int
+	var ttl time.Duration
 
 	flag.StringVar(&configPath, "config_path", "", "Path to the config file")
 	flag.StringVar(&method, "method", "", "Testing method")
 	flag.StringVar(&id, "id", "", "File id")
 	flag.StringVar(&imagePath, "image_path", "", "Path to the image")
+	flag.Int64Var(&offset, "offset", 0, "First byte of the file to download")
+	flag.Int64Var(&length, "length", 0, "Number of bytes to download, 0 downloads up to the end")
+	flag.StringVar(&token, "token", "", "Bearer JWT sent with every request")
+	flag.StringVar(&apiKey, "api_key", "", "API key sent with every request")
+	flag.DurationVar(&ttl, "ttl", 0, "Lifetime of the uploaded file, 0 keeps it until it is deleted, or of the created link")
+	flag.IntVar(&version, "version", 0, "File version, 0 stands for the current one")
+	flag.StringVar(&namePrefix, "name_prefix", "", "List only files whose name starts with the prefix")
+	flag.StringVar(&sortBy, "sort_by", "created_at", "Sort field of the list: created_at, updated_at, name or size")
+	flag.BoolVar(&ascending, "asc", false, "Sort the list in ascending order")
+	flag.StringVar(&grantee, "grantee", "", "User or group to grant or revoke access")
+	flag.BoolVar(&group, "group", false, "Treat the grantee as a group")
+	flag.StringVar(&permissions, "permissions", "read", "Comma separated permissions: read, write, delete")
+	flag.StringVar(&tenantID, "tenant", "", "Tenant of the call, or the tenant to create or disable")
+	flag.StringVar(&tenantName, "tenant_name", "", "Display name of the created tenant")
+	flag.IntVar(&maxUses, "max_uses", 0, "How many times the link may be used, 0 allows any number until it expires")
+	flag.BoolVar(&attachment, "attachment", false, "Make browsers save the file of the download link instead of displaying it")
+	flag.BoolVar(&useTLS, "tls", false, "Connect to the server over tls")
+	flag.StringVar(&caFile, "ca_file", "", "CA bundle to verify the server certificate, system roots are used if empty")
+	flag.StringVar(&certFile, "cert_file", "", "Client certificate for mutual tls")
+	flag.StringVar(&keyFile, "key_file", "", "Private key of the client certificate")
+	flag.StringVar(&serverName, "server_name", "", "Server name to verify the certificate against, the host is used if empty")
 	flag.Parse()
 
 	if configPath == "" {
@@ -50,7 +146,25 @@ func main() {
 	}
 
 	addr := fmt.Sprintf("%s:%d", cfg.GRPC.Host, cfg.GRPC.Port)
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	transportCreds := insecure.NewCredentials()
+	if useTLS {
+		transportCreds, err = tlsCredentials(caFile, certFile, keyFile, serverName)
+		if err != nil {
+			log.Fatal("cannot initialize tls credentials", zap.Error(err))
+		}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(transportCreds)}
+	if token != "" || apiKey != "" || tenantID != "" {
+		creds := &callCredentials{token: token, apiKey: apiKey}
+		if !strings.HasPrefix(method, "tenant-") {
+			creds.tenant = tenantID
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+
+	conn, err := grpc.NewClient(addr, opts...)
 	if err != nil {
 		log.Fatal("cannot connect to grpc server", zap.Error(err))
 	}
@@ -65,21 +179,60 @@ func main() {
 	client := fileservice.NewFileServiceClient(conn)
 
 	d := demo{
-		client: client,
-		logger: log,
+		client:       client,
+		tenantClient: fileservice.NewTenantServiceClient(conn),
+		logger:       log,
 	}
 
 	switch method {
 	case "upload":
-		d.demoUpload(imagePath)
+		d.demoUpload(imagePath, ttl)
+	case "resumable-upload":
+		d.demoResumableUpload(imagePath)
 	case "get":
-		d.demoGet(id)
+		d.demoGet(id, offset, length, int32(version))
 	case "list":
-		d.demoList()
+		d.demoList(namePrefix, sortBy, ascending)
+	case "delete":
+		d.demoDelete(id)
+	case "upload-version":
+		d.demoUploadVersion(id, imagePath)
+	case "versions":
+		d.demoVersions(id)
+	case "rollback":
+		d.demoRollback(id, int32(version))
+	case "trash":
+		d.demoTrash(id)
+	case "restore":
+		d.demoRestore(id)
+	case "trash-list":
+		d.demoTrashList()
+	case "grant":
+		d.demoGrant(id, grantee, group, permissions)
+	case "revoke":
+		d.demoRevoke(id, grantee, group, permissions)
+	case "access":
+		d.demoAccess(id)
+	case "tenant-create":
+		d.demoTenantCreate(tenantID, tenantName)
+	case "tenant-list":
+		d.demoTenantList()
+	case "tenant-disable":
+		d.demoTenantDisable(tenantID)
+	case "usage":
+		d.demoUsage()
+	case "download-link":
+		d.demoDownloadLink(id, ttl, int32(maxUses), attachment)
+	case "upload-link":
+		d.demoUploadLink(imagePath, ttl, int32(maxUses))
+	case "info":
+		d.demoInfo(id)
+	case "verify":
+		d.demoVerify(id)
 	}
 }
 
-func (d *demo) demoUpload(imagePath string) {
+func (d *demo) demoUpload(imagePath string, ttl time.Duration) {
 	if imagePath == "" {
 		d.logger.Fatal("demoUpload: image path is required")
 	}
@@ -90,6 +243,17 @@ func (d *demo) demoUpload(imagePath string) {
 	}
 	defer f.Close()
 
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		d.logger.Fatal("demoUpload: cannot hash file", zap.Error(err))
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		d.logger.Fatal("demoUpload: cannot rewind file", zap.Error(err))
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
 	stream, err := d.client.UploadFile(context.Background())
 	if err != nil {
 		d.logger.Fatal("demoUpload: cannot create upload stream", zap.Error(err))
@@ -106,8 +270,14 @@ func (d *demo) demoUpload(imagePath string) {
 		}
 
 		req := &fileservice.UploadFileRequest{
-			FileName: imagePath,
-			Chunk:    buf[:n],
+			FileName:    imagePath,
+			Chunk:       buf[:n],
+			ContentType: mime.TypeByExtension(filepath.Ext(imagePath)),
+			Sha256:      checksum,
+		}
+
+		if ttl > 0 {
+			req.Ttl = durationpb.New(ttl)
 		}
 
 		if err := stream.Send(req); err != nil {
@@ -123,12 +293,83 @@ func (d *demo) demoUpload(imagePath string) {
 	d.logger.Info("demoUpload: file uploaded successfully", zap.String("file_id", resp.GetFileId()))
 }
 
-func (d *demo) demoGet(fileID string) {
+// demoPartSize is the smallest part size accepted by the object storage for all parts except the last one.
+const demoPartSize = 5 * 1024 * 1024
+
+func (d *demo) demoResumableUpload(imagePath string) {
+	if imagePath == "" {
+		d.logger.Fatal("demoResumableUpload: image path is required")
+	}
+
+	data, err := os.ReadFile(imagePath)
+	if err != nil {
+		d.logger.Fatal("demoResumableUpload: cannot read file", zap.Error(err))
+	}
+
+	session, err := d.client.InitiateUpload(context.Background(), &fileservice.InitiateUploadRequest{
+		FileName:    imagePath,
+		ContentType: mime.TypeByExtension(filepath.Ext(imagePath)),
+	})
+	if err != nil {
+		d.logger.Fatal("demoResumableUpload: cannot initiate upload", zap.Error(err))
+	}
+
+	for number, offset := int32(1), 0; offset < len(data); number, offset = number+1, offset+demoPartSize {
+		part := data[offset:min(offset+demoPartSize, len(data))]
+
+		stream, err := d.client.UploadPart(context.Background())
+		if err != nil {
+			d.logger.Fatal("demoResumableUpload: cannot create part stream", zap.Error(err))
+		}
+
+		for sent := 0; sent < len(part); sent += 32 * 1024 {
+			req := &fileservice.UploadPartRequest{
+				SessionId:  session.GetSessionId(),
+				PartNumber: number,
+				Size:       int64(len(part)),
+				Chunk:      part[sent:min(sent+32*1024, len(part))],
+			}
+
+			if err := stream.Send(req); err != nil {
+				d.logger.Fatal("demoResumableUpload: failed to send chunk", zap.Error(err))
+			}
+		}
+
+		if _, err := stream.CloseAndRecv(); err != nil {
+			d.logger.Fatal("demoResumableUpload: failed to upload part", zap.Int32("part_number", number), zap.Error(err))
+		}
+	}
+
+	uploadStatus, err := d.client.GetUploadStatus(context.Background(), &fileservice.GetUploadStatusRequest{
+		SessionId: session.GetSessionId(),
+	})
+	if err != nil {
+		d.logger.Fatal("demoResumableUpload: failed to get upload status", zap.Error(err))
+	}
+
+	d.logger.Info("demoResumableUpload: parts uploaded", zap.Int("count", len(uploadStatus.GetParts())))
+
+	resp, err := d.client.CompleteUpload(context.Background(), &fileservice.CompleteUploadRequest{
+		SessionId: session.GetSessionId(),
+	})
+	if err != nil {
+		d.logger.Fatal("demoResumableUpload: failed to complete upload", zap.Error(err))
+	}
+
+	d.logger.Info("demoResumableUpload: file uploaded successfully", zap.String("file_id", resp.GetFileId()))
+}
+
+func (d *demo) demoGet(fileID string, offset int64, length int64, version int32) {
 	if fileID == "" {
 		d.logger.Fatal("demoGet: file ID is required")
 	}
 
-	stream, err := d.client.GetFile(context.Background(), &fileservice.GetFileRequest{FileId: fileID})
+	stream, err := d.client.GetFile(context.Background(), &fileservice.GetFileRequest{
+		FileId:  fileID,
+		Offset:  offset,
+		Length:  length,
+		Version: version,
+	})
 	if err != nil {
 		d.logger.Fatal("demoGet: failed to start stream", zap.Error(err))
 	}
@@ -139,6 +380,10 @@ func (d *demo) demoGet(fileID string) {
 	}
 	defer outFile.Close()
 
+	hash := sha256.New()
+	var expectedChecksum string
+	var wholeFile bool
+
 	for {
 		resp, err := stream.Recv()
 		if err == io.EOF {
@@ -148,26 +393,423 @@ func (d *demo) demoGet(fileID string) {
 			d.logger.Fatal("demoGet: failed to receive chunk", zap.Error(err))
 		}
 
+		if resp.GetFileName() != "" {
+			d.logger.Info("demoGet: range received",
+				zap.Int64("total_size", resp.GetTotalSize()),
+				zap.Int64("range_start", resp.GetRangeStart()),
+				zap.Int64("range_length", resp.GetRangeLength()),
+				zap.String("sha256", resp.GetSha256()),
+			)
+
+			expectedChecksum = resp.GetSha256()
+			wholeFile = resp.GetRangeLength() == resp.GetTotalSize()
+		}
+
+		hash.Write(resp.GetChunk())
+
 		if _, err := outFile.Write(resp.GetChunk()); err != nil {
 			d.logger.Fatal("demoGet: failed to write chunk to file", zap.Error(err))
 		}
 	}
 
+	if wholeFile && expectedChecksum != "" && expectedChecksum != hex.EncodeToString(hash.Sum(nil)) {
+		d.logger.Fatal("demoGet: checksum mismatch", zap.String("expected", expectedChecksum))
+	}
+
 	d.logger.Info("demoGet: file downloaded successfully", zap.String("file_name", outFile.Name()))
 }
 
-func (d *demo) demoList() {
-	resp, err := d.client.ListFiles(context.Background(), &fileservice.ListFilesRequest{
-		Limit:  10,
-		Offset: 0,
+func (d *demo) demoList(namePrefix string, sortBy string, ascending bool) {
+	sortField, ok := fileservice.SortField_value["SORT_FIELD_"+strings.ToUpper(sortBy)]
+	if !ok {
+		d.logger.Fatal("demoList: unknown sort field", zap.String("sort_by", sortBy))
+	}
+
+	direction := fileservice.SortDirection_SORT_DIRECTION_DESC
+	if ascending {
+		direction = fileservice.SortDirection_SORT_DIRECTION_ASC
+	}
+
+	var pageToken string
+
+	for page := 1; ; page++ {
+		resp, err := d.client.ListFiles(context.Background(), &fileservice.ListFilesRequest{
+			Limit:         10,
+			PageToken:     pageToken,
+			NamePrefix:    namePrefix,
+			SortBy:        fileservice.SortField(sortField),
+			SortDirection: direction,
+		})
+		if err != nil {
+			d.logger.Fatal("demoList: failed to list files", zap.Error(err))
+		}
+
+		d.logger.Info("demoList: files page retrieved", zap.Int("page", page), zap.Int("count", len(resp.Files)))
+		for _, file := range resp.Files {
+			fmt.Printf("id: %s, name: %s, status: %s, size: %d, content_type: %s, created_at: %s, updated_at: %s\n",
+				file.Id, file.Name, file.Status, file.Size, file.ContentType, file.CreatedAt.AsTime(), file.UpdatedAt.AsTime())
+		}
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+}
+
+func (d *demo) demoDelete(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoDelete: file ID is required")
+	}
+
+	_, err := d.client.DeleteFile(context.Background(), &fileservice.DeleteFileRequest{FileId: fileID})
+	if err != nil {
+		d.logger.Fatal("demoDelete: failed to delete file", zap.Error(err))
+	}
+
+	d.logger.Info("demoDelete: file deleted successfully", zap.String("file_id", fileID))
+}
+
+func (d *demo) demoUploadVersion(fileID string, imagePath string) {
+	if fileID == "" || imagePath == "" {
+		d.logger.Fatal("demoUploadVersion: file ID and image path are required")
+	}
+
+	f, err := os.Open(imagePath)
+	if err != nil {
+		d.logger.Fatal("demoUploadVersion: cannot open file", zap.Error(err))
+	}
+	defer f.Close()
+
+	stream, err := d.client.UploadFileVersion(context.Background())
+	if err != nil {
+		d.logger.Fatal("demoUploadVersion: cannot create upload stream", zap.Error(err))
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			d.logger.Fatal("demoUploadVersion: failed to read file", zap.Error(err))
+		}
+
+		req := &fileservice.UploadFileVersionRequest{
+			FileId:      fileID,
+			Chunk:       buf[:n],
+			ContentType: mime.TypeByExtension(filepath.Ext(imagePath)),
+		}
+
+		if err := stream.Send(req); err != nil {
+			d.logger.Fatal("demoUploadVersion: failed to send chunk", zap.Error(err))
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		d.logger.Fatal("demoUploadVersion: failed to close and receive", zap.Error(err))
+	}
+
+	d.logger.Info("demoUploadVersion: file version uploaded successfully",
+		zap.String("file_id", resp.GetFileId()),
+		zap.Int32("version", resp.GetVersion()),
+	)
+}
+
+func (d *demo) demoVersions(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoVersions: file ID is required")
+	}
+
+	resp, err := d.client.ListFileVersions(context.Background(), &fileservice.ListFileVersionsRequest{FileId: fileID})
+	if err != nil {
+		d.logger.Fatal("demoVersions: failed to list file versions", zap.Error(err))
+	}
+
+	for _, version := range resp.GetVersions() {
+		fmt.Printf("version: %d, current: %t, size: %d, sha256: %s, created_at: %s\n",
+			version.Version, version.Version == resp.GetCurrentVersion(), version.Size, version.Checksum, version.CreatedAt.AsTime())
+	}
+}
+
+func (d *demo) demoRollback(fileID string, version int32) {
+	if fileID == "" || version == 0 {
+		d.logger.Fatal("demoRollback: file ID and version are required")
+	}
+
+	_, err := d.client.RollbackFile(context.Background(), &fileservice.RollbackFileRequest{FileId: fileID, Version: version})
+	if err != nil {
+		d.logger.Fatal("demoRollback: failed to rollback file", zap.Error(err))
+	}
+
+	d.logger.Info("demoRollback: file rolled back successfully", zap.String("file_id", fileID), zap.Int32("version", version))
+}
+
+func (d *demo) demoTrash(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoTrash: file ID is required")
+	}
+
+	_, err := d.client.TrashFile(context.Background(), &fileservice.TrashFileRequest{FileId: fileID})
+	if err != nil {
+		d.logger.Fatal("demoTrash: failed to trash file", zap.Error(err))
+	}
+
+	d.logger.Info("demoTrash: file moved to trash successfully", zap.String("file_id", fileID))
+}
+
+func (d *demo) demoGrant(fileID string, grantee string, group bool, permissions string) {
+	if fileID == "" || grantee == "" {
+		d.logger.Fatal("demoGrant: file ID and grantee are required")
+	}
+
+	_, err := d.client.GrantAccess(context.Background(), &fileservice.GrantAccessRequest{
+		FileId:      fileID,
+		GranteeType: granteeType(group),
+		Grantee:     grantee,
+		Permissions: d.parsePermissions(permissions),
+	})
+	if err != nil {
+		d.logger.Fatal("demoGrant: failed to grant access", zap.Error(err))
+	}
+
+	d.logger.Info("demoGrant: access granted successfully", zap.String("file_id", fileID), zap.String("grantee", grantee))
+}
+
+func (d *demo) demoRevoke(fileID string, grantee string, group bool, permissions string) {
+	if fileID == "" || grantee == "" {
+		d.logger.Fatal("demoRevoke: file ID and grantee are required")
+	}
+
+	_, err := d.client.RevokeAccess(context.Background(), &fileservice.RevokeAccessRequest{
+		FileId:      fileID,
+		GranteeType: granteeType(group),
+		Grantee:     grantee,
+		Permissions: d.parsePermissions(permissions),
+	})
+	if err != nil {
+		d.logger.Fatal("demoRevoke: failed to revoke access", zap.Error(err))
+	}
+
+	d.logger.Info("demoRevoke: access revoked successfully", zap.String("file_id", fileID), zap.String("grantee", grantee))
+}
+
+func (d *demo) demoAccess(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoAccess: file ID is required")
+	}
+
+	resp, err := d.client.ListAccess(context.Background(), &fileservice.ListAccessRequest{FileId: fileID})
+	if err != nil {
+		d.logger.Fatal("demoAccess: failed to list access", zap.Error(err))
+	}
+
+	d.logger.Info("demoAccess: owner", zap.String("file_id", fileID), zap.String("owner", resp.GetOwner()))
+	for _, entry := range resp.GetEntries() {
+		d.logger.Info("demoAccess: entry",
+			zap.String("grantee_type", entry.GetGranteeType().String()),
+			zap.String("grantee", entry.GetGrantee()),
+			zap.String("permission", entry.GetPermission().String()),
+		)
+	}
+}
+
+func (d *demo) demoTenantCreate(id string, name string) {
+	if id == "" {
+		d.logger.Fatal("demoTenantCreate: tenant is required")
+	}
+
+	resp, err := d.tenantClient.CreateTenant(context.Background(), &fileservice.CreateTenantRequest{Id: id, Name: name})
+	if err != nil {
+		d.logger.Fatal("demoTenantCreate: failed to create tenant", zap.Error(err))
+	}
+
+	d.logger.Info("demoTenantCreate: tenant created successfully", zap.String("tenant", resp.GetTenant().GetId()))
+}
+
+func (d *demo) demoTenantList() {
+	resp, err := d.tenantClient.ListTenants(context.Background(), &fileservice.ListTenantsRequest{})
+	if err != nil {
+		d.logger.Fatal("demoTenantList: failed to list tenants", zap.Error(err))
+	}
+
+	for _, t := range resp.GetTenants() {
+		d.logger.Info("demoTenantList: tenant",
+			zap.String("id", t.GetId()),
+			zap.String("name", t.GetName()),
+			zap.Bool("disabled", t.GetDisabled()),
+		)
+	}
+}
+
+func (d *demo) demoTenantDisable(id string) {
+	if id == "" {
+		d.logger.Fatal("demoTenantDisable: tenant is required")
+	}
+
+	_, err := d.tenantClient.DisableTenant(context.Background(), &fileservice.DisableTenantRequest{Id: id})
+	if err != nil {
+		d.logger.Fatal("demoTenantDisable: failed to disable tenant", zap.Error(err))
+	}
+
+	d.logger.Info("demoTenantDisable: tenant disabled successfully", zap.String("tenant", id))
+}
+
+func granteeType(group bool) fileservice.GranteeType {
+	if group {
+		return fileservice.GranteeType_GRANTEE_TYPE_GROUP
+	}
+
+	return fileservice.GranteeType_GRANTEE_TYPE_USER
+}
+
+func (d *demo) parsePermissions(permissions string) []fileservice.Permission {
+	var parsed []fileservice.Permission
+
+	for _, name := range strings.Split(permissions, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		value, ok := fileservice.Permission_value["PERMISSION_"+strings.ToUpper(name)]
+		if !ok {
+			d.logger.Fatal("parsePermissions: unknown permission", zap.String("permission", name))
+		}
+
+		parsed = append(parsed, fileservice.Permission(value))
+	}
+
+	return parsed
+}
+
+func (d *demo) demoRestore(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoRestore: file ID is required")
+	}
+
+	_, err := d.client.RestoreFile(context.Background(), &fileservice.RestoreFileRequest{FileId: fileID})
+	if err != nil {
+		d.logger.Fatal("demoRestore: failed to restore file", zap.Error(err))
+	}
+
+	d.logger.Info("demoRestore: file restored successfully", zap.String("file_id", fileID))
+}
+
+func (d *demo) demoTrashList() {
+	var pageToken string
+
+	for {
+		resp, err := d.client.ListTrash(context.Background(), &fileservice.ListTrashRequest{
+			Limit:     10,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			d.logger.Fatal("demoTrashList: failed to list trash", zap.Error(err))
+		}
+
+		for _, file := range resp.Files {
+			fmt.Printf("id: %s, name: %s, size: %d, deleted_at: %s\n",
+				file.Id, file.Name, file.Size, file.DeletedAt.AsTime())
+		}
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+}
+
+func (d *demo) demoUsage() {
+	resp, err := d.client.GetUsage(context.Background(), &fileservice.GetUsageRequest{})
+	if err != nil {
+		d.logger.Fatal("demoUsage: failed to get usage", zap.Error(err))
+	}
+
+	fmt.Printf("tenant: %s, bytes: %d/%d, files: %d/%d\n",
+		resp.Tenant, resp.Bytes, resp.MaxBytes, resp.Files, resp.MaxFiles)
+}
+
+func (d *demo) demoDownloadLink(fileID string, ttl time.Duration, maxUses int32, attachment bool) {
+	if fileID == "" {
+		d.logger.Fatal("demoDownloadLink: file ID is required")
+	}
+
+	req := &fileservice.CreateDownloadLinkRequest{
+		FileId:     fileID,
+		MaxUses:    maxUses,
+		Attachment: attachment,
+	}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+
+	resp, err := d.client.CreateDownloadLink(context.Background(), req)
+	if err != nil {
+		d.logger.Fatal("demoDownloadLink: failed to create link", zap.Error(err))
+	}
+
+	fmt.Printf("url: %s, expires_at: %s\n", resp.Url, resp.ExpiresAt.AsTime())
+	fmt.Printf("curl -OJ '%s'\n", resp.Url)
+}
+
+func (d *demo) demoUploadLink(imagePath string, ttl time.Duration, maxUses int32) {
+	if imagePath == "" {
+		d.logger.Fatal("demoUploadLink: image path is required")
+	}
+
+	req := &fileservice.CreateUploadLinkRequest{
+		FileName:    filepath.Base(imagePath),
+		ContentType: mime.TypeByExtension(filepath.Ext(imagePath)),
+		MaxUses:     maxUses,
+	}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+
+	resp, err := d.client.CreateUploadLink(context.Background(), req)
+	if err != nil {
+		d.logger.Fatal("demoUploadLink: failed to create link", zap.Error(err))
+	}
+
+	fmt.Printf("url: %s, expires_at: %s\n", resp.Url, resp.ExpiresAt.AsTime())
+	fmt.Printf("curl -T %s '%s'\n", imagePath, resp.Url)
+}
+
+func (d *demo) demoInfo(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoInfo: file ID is required")
+	}
+
+	resp, err := d.client.GetFileInfo(context.Background(), &fileservice.GetFileInfoRequest{
+		FileId:      fileID,
+		CheckObject: true,
 	})
 	if err != nil {
-		d.logger.Fatal("demoList: failed to list files", zap.Error(err))
+		d.logger.Fatal("demoInfo: failed to get file info", zap.Error(err))
+	}
+
+	file := resp.GetFile()
+	fmt.Printf("id: %s, name: %s, size: %d, content_type: %s, checksum: %s, status: %s, created_at: %s, updated_at: %s, object_exists: %t\n",
+		file.Id, file.Name, file.Size, file.ContentType, file.Checksum, file.Status,
+		file.CreatedAt.AsTime(), file.UpdatedAt.AsTime(), resp.ObjectExists)
+}
+
+func (d *demo) demoVerify(fileID string) {
+	if fileID == "" {
+		d.logger.Fatal("demoVerify: file ID is required")
 	}
 
-	d.logger.Info("demoList: files list retrieved", zap.Int("count", len(resp.Files)))
-	for _, file := range resp.Files {
-		fmt.Printf("id: %s, created_at: %s, updated_at: %s\n",
-			file.Name, file.CreatedAt.AsTime(), file.UpdatedAt.AsTime())
+	resp, err := d.client.VerifyFile(context.Background(), &fileservice.VerifyFileRequest{FileId: fileID})
+	if err != nil {
+		d.logger.Fatal("demoVerify: failed to verify file", zap.Error(err))
 	}
+
+	d.logger.Info("demoVerify: file verified",
+		zap.Bool("valid", resp.GetValid()),
+		zap.String("expected_sha256", resp.GetExpectedSha256()),
+		zap.String("actual_sha256", resp.GetActualSha256()),
+	)
 }
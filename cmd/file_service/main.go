@@ -7,15 +7,20 @@ import (
 	stdlog "log"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"go.uber.org/zap"
 
 	"fileservice/internal/config"
 	"fileservice/internal/grpc/grpc_app"
+	"fileservice/internal/http/http_app"
 	"fileservice/internal/logger"
+	"fileservice/internal/metrics"
 	"fileservice/internal/sorage/minio"
 	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tracing"
+	"fileservice/internal/worker"
 )
 
 // TODO: написать README в protos
@@ -44,6 +49,11 @@ func main() {
 		stdlog.Fatalf("cannot initialize logger: %v", err)
 	}
 
+	shutdownTracing, err := tracing.New(ctx, &cfg.Tracing, log)
+	if err != nil {
+		log.Fatal("cannot initialize tracing", zap.Error(err))
+	}
+
 	minioStorage, err := minio.New(ctx, cfg.Minio, log)
 	if err != nil {
 		log.Fatal("cannot initialize minio", zap.Error(err))
@@ -54,7 +64,36 @@ func main() {
 		log.Fatal("cannot initialize postgres", zap.Error(err))
 	}
 
-	application := grpcapp.New(minioStorage, postgresStorage, log, &cfg.GRPC)
+	appMetrics := metrics.New(&cfg.GRPC.Metrics, log)
+	minioStorage.OnRetry = appMetrics.RetryHook("minio")
+	postgresStorage.OnRetry = appMetrics.RetryHook("postgres")
+
+	go func() {
+		err := appMetrics.Start()
+		if err != nil {
+			log.Fatal("cannot start metrics server", zap.Error(err))
+		}
+	}()
+
+	// The workers are waited for on shutdown, so none of them uses the storages after they are closed.
+	var workers sync.WaitGroup
+
+	sessionCollector := worker.NewSessionCollector(minioStorage, postgresStorage, &cfg.Worker, log)
+	workers.Go(func() { sessionCollector.Run(ctx) })
+
+	reconciler := worker.NewReconciler(minioStorage, postgresStorage, &cfg.Worker, log)
+	workers.Go(func() { reconciler.Run(ctx) })
+
+	trashPurger := worker.NewTrashPurger(minioStorage, postgresStorage, &cfg.Worker, log)
+	workers.Go(func() { trashPurger.Run(ctx) })
+
+	expiryCollector := worker.NewExpiryCollector(minioStorage, postgresStorage, &cfg.Worker, log)
+	workers.Go(func() { expiryCollector.Run(ctx) })
+
+	application, err := grpcapp.New(minioStorage, postgresStorage, log, &cfg.GRPC, appMetrics)
+	if err != nil {
+		log.Fatal("cannot initialize grpc server", zap.Error(err))
+	}
 
 	go func() {
 		err = application.Start()
@@ -63,14 +102,65 @@ func main() {
 		}
 	}()
 
+	var httpApplication *httpapp.App
+	if cfg.HTTP.Enabled {
+		httpApplication = httpapp.New(application.LinkHandler(), log, &cfg.HTTP)
+
+		go func() {
+			err := httpApplication.Start()
+			if err != nil {
+				log.Fatal("cannot start http server", zap.Error(err))
+			}
+		}()
+	}
+
+	// SIGHUP rereads the config and applies the bandwidth limits from it, the rest of the config needs a restart.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	go func() {
+		for range reload {
+			newCfg, err := config.New(configPath)
+			if err != nil {
+				log.Error("cannot reload config", zap.Error(err))
+				continue
+			}
+
+			application.SetBandwidth(newCfg.GRPC.Bandwidth)
+		}
+	}()
+
 	<-ctx.Done()
 	log.Info("received shutdown signal")
 
+	if httpApplication != nil {
+		err := httpApplication.Stop()
+		if err != nil {
+			log.Error("cannot gracefully stop http server", zap.Error(err))
+		}
+	}
+
 	application.Stop()
 	if err != nil {
 		log.Fatal("cannot gracefully stop grpc server", zap.Error(err))
 	}
 
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), cfg.GRPC.ShutdownTimeout)
+	defer stopCancel()
+
+	err = appMetrics.Stop(stopCtx)
+	if err != nil {
+		log.Error("cannot gracefully stop metrics server", zap.Error(err))
+	}
+
+	err = shutdownTracing(stopCtx)
+	if err != nil {
+		log.Error("cannot flush traces", zap.Error(err))
+	}
+
+	application.WaitBackground(stopCtx)
+	workers.Wait()
 	postgresStorage.Close()
 
 	log.Info("stopping http service", zap.String("addr", fmt.Sprintf("%s:%d", cfg.GRPC.Host, cfg.GRPC.Port)))
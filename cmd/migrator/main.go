@@ -3,7 +3,6 @@ package main
 import (
 	"errors"
 	"flag"
-	"fmt"
 	"log"
 
 	"github.com/golang-migrate/migrate"
@@ -13,6 +12,7 @@ import (
 
 	cconfig "fileservice/internal/config"
 	llogger "fileservice/internal/logger"
+	"fileservice/internal/sorage/postgres"
 )
 
 func main() {
@@ -32,13 +32,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	url := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		config.Postgres.User,
-		config.Postgres.Password,
-		config.Postgres.Host,
-		config.Postgres.Port,
-		config.Postgres.Database,
-	)
+	url := postgres.MigrationURL(&config.Postgres)
 
 	migration, err := migrate.New("file://"+migrationPath, url)
 	if err != nil {
@@ -0,0 +1,4 @@
+alter table schema_files.table_files
+    drop column if exists size,
+    drop column if exists content_type,
+    drop column if exists checksum;
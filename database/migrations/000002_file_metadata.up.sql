@@ -0,0 +1,4 @@
+alter table schema_files.table_files
+    add column if not exists size bigint not null default 0,
+    add column if not exists content_type text not null default 'application/octet-stream',
+    add column if not exists checksum text not null default '';
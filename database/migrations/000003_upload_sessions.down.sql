@@ -0,0 +1,3 @@
+drop table if exists schema_files.table_upload_parts;
+
+drop table if exists schema_files.table_upload_sessions;
@@ -0,0 +1,23 @@
+create table if not exists schema_files.table_upload_sessions
+(
+    id uuid primary key,
+    file_id uuid not null,
+    upload_id text not null,
+    name text not null,
+    content_type text not null,
+    status text not null,
+    created_at timestamptz not null,
+    expires_at timestamptz not null
+);
+
+create index if not exists idx_upload_sessions_expires_at on schema_files.table_upload_sessions (expires_at);
+
+create table if not exists schema_files.table_upload_parts
+(
+    session_id uuid not null references schema_files.table_upload_sessions (id) on delete cascade,
+    part_number int not null,
+    etag text not null,
+    size bigint not null,
+    created_at timestamptz not null,
+    primary key (session_id, part_number)
+);
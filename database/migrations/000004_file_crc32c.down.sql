@@ -0,0 +1,2 @@
+alter table schema_files.table_files
+    drop column if exists crc32c;
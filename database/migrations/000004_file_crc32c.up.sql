@@ -0,0 +1,2 @@
+alter table schema_files.table_files
+    add column if not exists crc32c text not null default '';
@@ -0,0 +1,7 @@
+drop index if exists schema_files.idx_files_blob_hash;
+
+alter table schema_files.table_files
+    drop column if exists blob_hash,
+    drop column if exists object_key;
+
+drop table if exists schema_files.table_blobs;
@@ -0,0 +1,18 @@
+create table if not exists schema_files.table_blobs
+(
+    hash text primary key,
+    size bigint not null,
+    ref_count bigint not null,
+    created_at timestamptz not null
+);
+
+alter table schema_files.table_files
+    add column if not exists object_key text,
+    add column if not exists blob_hash text references schema_files.table_blobs (hash);
+
+update schema_files.table_files set object_key = id::text where object_key is null;
+
+alter table schema_files.table_files
+    alter column object_key set not null;
+
+create index if not exists idx_files_blob_hash on schema_files.table_files (blob_hash);
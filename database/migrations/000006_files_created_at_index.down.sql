@@ -0,0 +1 @@
+drop index if exists schema_files.idx_files_created_at_id;
@@ -0,0 +1 @@
+create index if not exists idx_files_created_at_id on schema_files.table_files (created_at desc, id desc);
@@ -0,0 +1,13 @@
+drop index if exists schema_files.idx_files_content_type;
+
+drop index if exists schema_files.idx_files_status_created_at_id;
+
+drop index if exists schema_files.idx_files_size_id;
+
+drop index if exists schema_files.idx_files_updated_at_id;
+
+drop index if exists schema_files.idx_files_name_id;
+
+drop index if exists schema_files.idx_files_name_trgm;
+
+drop index if exists schema_files.idx_files_name_pattern;
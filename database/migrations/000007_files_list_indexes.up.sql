@@ -0,0 +1,15 @@
+create extension if not exists pg_trgm;
+
+create index if not exists idx_files_name_pattern on schema_files.table_files (name text_pattern_ops);
+
+create index if not exists idx_files_name_trgm on schema_files.table_files using gin (name gin_trgm_ops);
+
+create index if not exists idx_files_name_id on schema_files.table_files (name, id);
+
+create index if not exists idx_files_updated_at_id on schema_files.table_files (updated_at, id);
+
+create index if not exists idx_files_size_id on schema_files.table_files (size, id);
+
+create index if not exists idx_files_status_created_at_id on schema_files.table_files (status, created_at desc, id desc);
+
+create index if not exists idx_files_content_type on schema_files.table_files (content_type);
@@ -0,0 +1,3 @@
+drop index if exists schema_files.idx_files_status_updated_at;
+
+drop index if exists schema_files.idx_files_object_key;
@@ -0,0 +1,3 @@
+create index if not exists idx_files_object_key on schema_files.table_files (object_key);
+
+create index if not exists idx_files_status_updated_at on schema_files.table_files (status, updated_at);
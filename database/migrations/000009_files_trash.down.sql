@@ -0,0 +1,6 @@
+drop index if exists schema_files.idx_files_trashed_deleted_at;
+
+update schema_files.table_files set status = 'success' where status = 'trashed';
+
+alter table schema_files.table_files
+    drop column if exists deleted_at;
@@ -0,0 +1,5 @@
+alter table schema_files.table_files
+    add column if not exists deleted_at timestamptz;
+
+create index if not exists idx_files_trashed_deleted_at on schema_files.table_files (deleted_at)
+    where status = 'trashed';
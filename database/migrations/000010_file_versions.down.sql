@@ -0,0 +1,4 @@
+alter table schema_files.table_files
+    drop column if exists version;
+
+drop table if exists schema_files.table_file_versions;
@@ -0,0 +1,27 @@
+create table if not exists schema_files.table_file_versions
+(
+    file_id uuid not null references schema_files.table_files (id) on delete cascade,
+    version int not null,
+    object_key text not null,
+    blob_hash text references schema_files.table_blobs (hash),
+    size bigint not null,
+    content_type text not null,
+    checksum text not null,
+    crc32c text not null,
+    created_at timestamptz not null,
+    primary key (file_id, version)
+);
+
+create index if not exists idx_file_versions_object_key on schema_files.table_file_versions (object_key);
+
+alter table schema_files.table_files
+    add column if not exists version int not null default 0;
+
+insert into schema_files.table_file_versions
+    (file_id, version, object_key, blob_hash, size, content_type, checksum, crc32c, created_at)
+select id, 1, object_key, blob_hash, size, content_type, checksum, crc32c, updated_at
+from schema_files.table_files
+where status <> 'pending'
+on conflict do nothing;
+
+update schema_files.table_files set version = 1 where status <> 'pending';
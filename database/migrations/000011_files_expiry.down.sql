@@ -0,0 +1,4 @@
+drop index if exists schema_files.idx_files_expires_at;
+
+alter table schema_files.table_files
+    drop column if exists expires_at;
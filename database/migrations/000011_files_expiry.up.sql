@@ -0,0 +1,5 @@
+alter table schema_files.table_files
+    add column if not exists expires_at timestamptz;
+
+create index if not exists idx_files_expires_at on schema_files.table_files (expires_at)
+    where expires_at is not null;
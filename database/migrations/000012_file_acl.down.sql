@@ -0,0 +1,9 @@
+drop table if exists schema_files.table_file_acl;
+
+drop index if exists schema_files.idx_files_owner;
+
+alter table schema_files.table_upload_sessions
+    drop column if exists owner;
+
+alter table schema_files.table_files
+    drop column if exists owner;
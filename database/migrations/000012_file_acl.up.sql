@@ -0,0 +1,19 @@
+alter table schema_files.table_files
+    add column if not exists owner text;
+
+alter table schema_files.table_upload_sessions
+    add column if not exists owner text;
+
+create index if not exists idx_files_owner on schema_files.table_files (owner);
+
+create table if not exists schema_files.table_file_acl
+(
+    file_id uuid not null references schema_files.table_files (id) on delete cascade,
+    grantee_type text not null,
+    grantee text not null,
+    permission text not null,
+    created_at timestamptz not null,
+    primary key (file_id, grantee_type, grantee, permission)
+);
+
+create index if not exists idx_file_acl_grantee on schema_files.table_file_acl (grantee_type, grantee, permission);
@@ -0,0 +1,9 @@
+drop index if exists schema_files.idx_files_tenant_created_at;
+
+alter table schema_files.table_upload_sessions
+    drop column if exists tenant;
+
+alter table schema_files.table_files
+    drop column if exists tenant;
+
+drop table if exists schema_files.table_tenants;
@@ -0,0 +1,19 @@
+create table if not exists schema_files.table_tenants
+(
+    id text primary key,
+    name text not null,
+    disabled boolean not null default false,
+    created_at timestamptz not null
+);
+
+insert into schema_files.table_tenants (id, name, created_at)
+values ('default', 'default', now())
+on conflict do nothing;
+
+alter table schema_files.table_files
+    add column if not exists tenant text not null default 'default' references schema_files.table_tenants (id);
+
+alter table schema_files.table_upload_sessions
+    add column if not exists tenant text not null default 'default' references schema_files.table_tenants (id);
+
+create index if not exists idx_files_tenant_created_at on schema_files.table_files (tenant, created_at, id);
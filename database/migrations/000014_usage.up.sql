@@ -0,0 +1,16 @@
+create table if not exists schema_files.table_usage
+(
+    tenant text primary key references schema_files.table_tenants (id),
+    bytes bigint not null default 0,
+    files bigint not null default 0
+);
+
+insert into schema_files.table_usage (tenant, bytes, files)
+select t.id,
+       coalesce((select sum(v.size)
+                 from schema_files.table_file_versions v
+                          join schema_files.table_files f on f.id = v.file_id
+                 where f.tenant = t.id), 0),
+       (select count(*) from schema_files.table_files f where f.tenant = t.id)
+from schema_files.table_tenants t
+on conflict (tenant) do nothing;
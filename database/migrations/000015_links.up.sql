@@ -0,0 +1,17 @@
+create table if not exists schema_files.table_links
+(
+    id text primary key,
+    kind text not null,
+    tenant text not null references schema_files.table_tenants (id),
+    file_id uuid references schema_files.table_files (id) on delete cascade,
+    name text not null,
+    content_type text not null,
+    owner text,
+    attachment boolean not null default false,
+    max_uses integer not null default 0,
+    uses integer not null default 0,
+    expires_at timestamptz not null,
+    created_at timestamptz not null
+);
+
+create index if not exists idx_links_expires_at on schema_files.table_links (expires_at);
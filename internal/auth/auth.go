@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	ErrNoCredentials      = errors.New("no credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+const (
+	authorizationHeader = "authorization"
+	apiKeyHeader        = "x-api-key"
+	bearerPrefix        = "bearer "
+)
+
+// defaultPublicMethods lets orchestrators and tooling probe the server without credentials.
+var defaultPublicMethods = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+func New(config *Config) (*Authenticator, error) {
+	a := &Authenticator{
+		enabled:       config.Enabled,
+		issuer:        config.JWT.Issuer,
+		audience:      config.JWT.Audience,
+		groupsClaim:   config.JWT.GroupsClaim,
+		tenantClaim:   config.JWT.TenantClaim,
+		apiKeys:       make(map[string]*APIKey, len(config.APIKeys)),
+		publicMethods: append(append([]string{}, defaultPublicMethods...), config.PublicMethods...),
+	}
+
+	if config.JWT.HMACSecret != "" {
+		a.hmacSecret = []byte(config.JWT.HMACSecret)
+	}
+
+	if config.JWT.RSAPublicKeyFile != "" {
+		key, err := loadRSAPublicKey(config.JWT.RSAPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("New: %w", err)
+		}
+
+		a.rsaKey = key
+	}
+
+	if config.JWT.JWKSFile != "" {
+		jwks, err := loadJWKS(config.JWT.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("New: %w", err)
+		}
+
+		a.jwks = jwks
+	}
+
+	for i := range config.APIKeys {
+		key := &config.APIKeys[i]
+
+		hash, err := hex.DecodeString(key.Hash)
+		if err != nil || len(hash) != sha256.Size || key.Subject == "" {
+			return nil, fmt.Errorf("New: api key %d must have a subject and a hex encoded SHA-256 hash", i)
+		}
+
+		a.apiKeys[strings.ToLower(key.Hash)] = key
+	}
+
+	return a, nil
+}
+
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// IsPublic reports whether the method is served without credentials.
+func (a *Authenticator) IsPublic(fullMethod string) bool {
+	for _, method := range a.publicMethods {
+		if method == fullMethod || (strings.HasSuffix(method, "/") && strings.HasPrefix(fullMethod, method)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticate checks the bearer token or the api key sent in the request metadata.
+// Without them the verified client certificate identifies the caller.
+func (a *Authenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get(apiKeyHeader); len(keys) > 0 {
+		return a.authenticateAPIKey(keys[0])
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		if principal, ok := PrincipalFromPeer(ctx); ok {
+			return principal, nil
+		}
+
+		return nil, ErrNoCredentials
+	}
+
+	value := values[0]
+	if len(value) < len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return nil, fmt.Errorf("%w: unsupported authorization scheme", ErrInvalidCredentials)
+	}
+
+	return a.authenticateJWT(strings.TrimSpace(value[len(bearerPrefix):]))
+}
+
+func (a *Authenticator) authenticateAPIKey(key string) (*Principal, error) {
+	sum := sha256.Sum256([]byte(key))
+
+	// The lookup is done by the hash, so the time it takes does not depend on how much of a valid key was guessed.
+	apiKey, ok := a.apiKeys[hex.EncodeToString(sum[:])]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+	}
+
+	return &Principal{
+		Subject: apiKey.Subject,
+		Groups:  apiKey.Groups,
+		Method:  MethodAPIKey,
+		Tenant:  apiKey.Tenant,
+	}, nil
+}
+
+func (a *Authenticator) authenticateJWT(tokenString string) (*Principal, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if a.issuer != "" {
+		options = append(options, jwt.WithIssuer(a.issuer))
+	}
+	if a.audience != "" {
+		options = append(options, jwt.WithAudience(a.audience))
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, a.keyFunc, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+
+	return &Principal{
+		Subject: subject,
+		Groups:  stringsClaim(claims[a.groupsClaim]),
+		Method:  MethodJWT,
+		Tenant:  tenantClaim(claims[a.tenantClaim]),
+	}, nil
+}
+
+// keyFunc picks the verification key by the algorithm of the token, RS256 tokens with a kid are checked against the JWKS.
+func (a *Authenticator) keyFunc(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if a.hmacSecret == nil {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+
+		return a.hmacSecret, nil
+
+	case jwt.SigningMethodRS256.Alg():
+		if kid, ok := token.Header["kid"].(string); ok && a.jwks != nil {
+			key, ok := a.jwks[kid]
+			if !ok {
+				return nil, fmt.Errorf("unknown key id %q", kid)
+			}
+
+			return key, nil
+		}
+
+		if a.rsaKey == nil {
+			return nil, errors.New("RS256 tokens are not accepted")
+		}
+
+		return a.rsaKey, nil
+	}
+
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+func tenantClaim(value any) string {
+	tenant, _ := value.(string)
+	return tenant
+}
+
+func stringsClaim(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+
+		return result
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"slices"
+)
+
+type Config struct {
+	Enabled bool      `yaml:"enabled" env-default:"false"`
+	JWT     JWTConfig `yaml:"jwt"`
+	APIKeys []APIKey  `yaml:"api_keys"`
+	// PublicMethods are full gRPC method names or service prefixes ending with a slash which are served without credentials.
+	PublicMethods []string `yaml:"public_methods"`
+	// AdminGroups are allowed to manage tenants.
+	AdminGroups []string `yaml:"admin_groups"`
+}
+
+type JWTConfig struct {
+	HMACSecret       string `yaml:"hmac_secret"`
+	RSAPublicKeyFile string `yaml:"rsa_public_key_file"`
+	JWKSFile         string `yaml:"jwks_file"`
+	Issuer           string `yaml:"issuer"`
+	Audience         string `yaml:"audience"`
+	GroupsClaim      string `yaml:"groups_claim" env-default:"groups"`
+	TenantClaim      string `yaml:"tenant_claim" env-default:"tenant"`
+}
+
+// APIKey is a static key, only its hex encoded SHA-256 is kept in the config.
+type APIKey struct {
+	Subject string   `yaml:"subject"`
+	Hash    string   `yaml:"hash"`
+	Groups  []string `yaml:"groups"`
+	// Tenant binds the key to a tenant, an empty one lets the caller pick it in the request metadata.
+	Tenant string `yaml:"tenant"`
+}
+
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+// Principal is the authenticated caller.
+type Principal struct {
+	Subject string
+	Groups  []string
+	Method  string
+	// Tenant is set when the credentials are bound to a tenant.
+	Tenant string
+}
+
+// InGroup reports whether the principal belongs to one of the groups.
+func (p *Principal) InGroup(groups []string) bool {
+	for _, group := range groups {
+		if slices.Contains(p.Groups, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Authenticator struct {
+	enabled       bool
+	hmacSecret    []byte
+	rsaKey        *rsa.PublicKey
+	jwks          map[string]*rsa.PublicKey
+	issuer        string
+	audience      string
+	groupsClaim   string
+	tenantClaim   string
+	apiKeys       map[string]*APIKey
+	publicMethods []string
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const MethodTLS = "tls"
+
+// PrincipalFromPeer returns the identity of a verified client certificate: the common name of its subject,
+// or the first SAN when the subject has none. Organizational units become groups.
+func PrincipalFromPeer(ctx context.Context) (*Principal, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	cert := info.State.VerifiedChains[0][0]
+
+	subject := certIdentity(cert)
+	if subject == "" {
+		return nil, false
+	}
+
+	return &Principal{
+		Subject: subject,
+		Groups:  cert.Subject.OrganizationalUnit,
+		Method:  MethodTLS,
+	}, true
+}
+
+func certIdentity(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	}
+
+	return ""
+}
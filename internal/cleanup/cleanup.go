@@ -0,0 +1,60 @@
+// Package cleanup removes files together with their objects. It is shared by the service and the background workers,
+// so both release the deduplicated blobs the same way.
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+)
+
+type ObjectRemover interface {
+	RemoveObject(ctx context.Context, id string) error
+}
+
+type FileInfoRemover interface {
+	ListFileVersions(ctx context.Context, id string) ([]*postgres.FileVersion, error)
+	DeleteFileInfo(ctx context.Context, id string) ([]string, error)
+}
+
+// RemoveFile removes the content of every version and the row of a file already marked as deleting.
+// A shared blob is only removed together with its last reference.
+func RemoveFile(ctx context.Context, objectStorage ObjectRemover, metaStorage FileInfoRemover, file *postgres.File, logger *zap.Logger) error {
+	id := file.Info.GetId()
+
+	versions, err := metaStorage.ListFileVersions(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RemoveFile: failed to list file versions: %w", err)
+	}
+
+	for _, key := range file.OwnObjectKeys(versions) {
+		err = objectStorage.RemoveObject(ctx, key)
+		if err != nil {
+			return fmt.Errorf("RemoveFile: failed to remove object: %w", err)
+		}
+	}
+
+	return RemoveFileInfo(ctx, objectStorage, metaStorage, id, logger)
+}
+
+// RemoveFileInfo deletes the row of the file and the blob objects nobody refers to anymore. A missing row is not an error.
+func RemoveFileInfo(ctx context.Context, objectStorage ObjectRemover, metaStorage FileInfoRemover, id string, logger *zap.Logger) error {
+	unusedBlobs, err := metaStorage.DeleteFileInfo(ctx, id)
+	if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+		return fmt.Errorf("RemoveFileInfo: failed to delete file info: %w", err)
+	}
+
+	for _, hash := range unusedBlobs {
+		err = objectStorage.RemoveObject(ctx, minio.BlobKey(hash))
+		if err != nil {
+			logger.Warn("RemoveFileInfo: failed to remove blob object", zap.String("hash", hash), zap.Error(err))
+		}
+	}
+
+	return nil
+}
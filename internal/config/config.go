@@ -7,15 +7,21 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 
 	"fileservice/internal/grpc/grpc_app"
+	"fileservice/internal/http/http_app"
 	"fileservice/internal/sorage/minio"
 	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tracing"
+	"fileservice/internal/worker"
 )
 
 type Config struct {
 	Env      string          `yaml:"env" env-required:"true"`
 	GRPC     grpcapp.Config  `yaml:"grpc" env-required:"true"`
+	HTTP     httpapp.Config  `yaml:"http"`
 	Postgres postgres.Config `yaml:"postgres" env-required:"true"`
 	Minio    minio.Config    `yaml:"minio" env-required:"true"`
+	Worker   worker.Config   `yaml:"worker"`
+	Tracing  tracing.Config  `yaml:"tracing"`
 }
 
 func New(path string) (*Config, error) {
@@ -33,5 +39,10 @@ func New(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
+	// A live upload refreshes its row, the reconciler must wait longer than that not to roll it back.
+	if cfg.GRPC.PendingRefresh <= 0 || cfg.GRPC.PendingRefresh >= cfg.Worker.StaleUploadAge {
+		return nil, fmt.Errorf("grpc.pending_refresh_interval must be positive and shorter than worker.stale_upload_age")
+	}
+
 	return &cfg, nil
 }
@@ -1,79 +1,220 @@
 package grpcapp
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"time"
 
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"fileservice/internal/auth"
 	"fileservice/internal/grpc/interceptor"
 	"fileservice/internal/grpc/service"
+	"fileservice/internal/health"
 	"fileservice/internal/limiter"
+	"fileservice/internal/links"
+	"fileservice/internal/metrics"
+	"fileservice/internal/quota"
+	"fileservice/internal/tlsconfig"
 )
 
 type Config struct {
-	Host             string        `yaml:"host" env-required:"true"`
-	Port             int           `yaml:"port" env-required:"true"`
-	OperationTimeout time.Duration `yaml:"operation_timeout" env-required:"true"`
-	ShutdownTimeout  time.Duration `yaml:"shutdown_timeout" env-required:"true"`
-	LoadConcurrent   int           `yaml:"load_concurrent" env-required:"true"`
-	ReadConcurrent   int           `yaml:"read_concurrent" env-required:"true"`
-	IdleTTL          time.Duration `yaml:"idle_ttl: 10m" env-default:"10m"`
-	BufSize          int           `yaml:"grpc_stream_buf_size" env-required:"true"`
-	MaxLimit         int64         `yaml:"max_limit" env-required:"true"`
-	DefaultLimit     int64         `yaml:"default_limit" env-required:"true"`
-	MaxOffset        int64         `yaml:"max_offset" env-required:"true"`
-	DefaultOffset    int64         `yaml:"default_offset" env-required:"true"`
+	Host             string                  `yaml:"host" env-required:"true"`
+	Port             int                     `yaml:"port" env-required:"true"`
+	OperationTimeout time.Duration           `yaml:"operation_timeout" env-required:"true"`
+	ShutdownTimeout  time.Duration           `yaml:"shutdown_timeout" env-required:"true"`
+	TransferTimeout  time.Duration           `yaml:"transfer_timeout" env-default:"0"`
+	PendingRefresh   time.Duration           `yaml:"pending_refresh_interval" env-default:"10m"`
+	LoadConcurrent   int                     `yaml:"load_concurrent" env-required:"true"`
+	ReadConcurrent   int                     `yaml:"read_concurrent" env-required:"true"`
+	IdleTTL          time.Duration           `yaml:"idle_ttl: 10m" env-default:"10m"`
+	LoadRate         float64                 `yaml:"load_rate" env-default:"0"`
+	LoadBurst        int                     `yaml:"load_burst" env-default:"0"`
+	ReadRate         float64                 `yaml:"read_rate" env-default:"0"`
+	ReadBurst        int                     `yaml:"read_burst" env-default:"0"`
+	Bandwidth        limiter.BandwidthConfig `yaml:"bandwidth"`
+	BufSize          int                     `yaml:"grpc_stream_buf_size" env-required:"true"`
+	MaxLimit         int64                   `yaml:"max_limit" env-required:"true"`
+	DefaultLimit     int64                   `yaml:"default_limit" env-required:"true"`
+	MaxOffset        int64                   `yaml:"max_offset" env-required:"true"`
+	DefaultOffset    int64                   `yaml:"default_offset" env-required:"true"`
+	UploadSessionTTL time.Duration           `yaml:"upload_session_ttl" env-default:"24h"`
+	Dedup            bool                    `yaml:"dedup" env-default:"false"`
+	Auth             auth.Config             `yaml:"auth"`
+	TLS              tlsconfig.Config        `yaml:"tls"`
+	Quota            quota.Config            `yaml:"quota"`
+	Links            links.Config            `yaml:"links"`
+	Metrics          metrics.Config          `yaml:"metrics"`
+	Health           health.Config           `yaml:"health"`
+}
+
+// ObjectStorage and MetaStorage are probed by the health service besides serving the calls.
+type ObjectStorage interface {
+	service.ObjectStorage
+	Ping(ctx context.Context) error
+}
+
+type MetaStorage interface {
+	service.MetaStorage
+	Ping(ctx context.Context) error
 }
 
 type App struct {
 	gRPCServer       *grpc.Server
+	limiter          *limiter.Registry
+	tlsReloader      *tlsconfig.Reloader
+	linkHandler      http.Handler
+	background       *service.Background
+	stopReload       context.CancelFunc
+	healthServer     *grpchealth.Server
+	stopProbe        context.CancelFunc
+	drainDelay       time.Duration
 	host             string
 	port             int
 	operationTimeout time.Duration
 	logger           *zap.Logger
 }
 
-func New(objectStorage service.ObjectStorage, metaStorage service.MetaStorage, log *zap.Logger, config *Config) *App {
-	lim := limiter.NewRegistry(config.LoadConcurrent, config.ReadConcurrent, config.IdleTTL)
+func New(
+	objectStorage ObjectStorage,
+	metaStorage MetaStorage,
+	log *zap.Logger,
+	config *Config,
+	appMetrics *metrics.Metrics,
+) (*App, error) {
+	lim := limiter.NewRegistry(&limiter.Config{
+		LoadConcurrent: config.LoadConcurrent,
+		ReadConcurrent: config.ReadConcurrent,
+		TTL:            config.IdleTTL,
+		LoadRate:       config.LoadRate,
+		LoadBurst:      config.LoadBurst,
+		ReadRate:       config.ReadRate,
+		ReadBurst:      config.ReadBurst,
+		Bandwidth:      config.Bandwidth,
+	})
 
+	authenticator, err := auth.New(&config.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("New: cannot initialize authenticator: %w", err)
+	}
+
+	authInterceptor := interceptor.NewAuthInterceptor(authenticator, log)
+	tenantInterceptor := interceptor.NewTenantInterceptor(metaStorage, log)
 	concurrencyInterceptor := interceptor.NewConcurrencyInterceptor(lim, log)
+	concurrencyInterceptor.OnAcquire = appMetrics.LimiterAcquired
+	concurrencyInterceptor.OnRelease = appMetrics.LimiterReleased
+	concurrencyInterceptor.OnReject = appMetrics.LimiterRejected
+	concurrencyInterceptor.OnRateLimit = appMetrics.RateLimited
+	metricsInterceptor := interceptor.NewMetricsInterceptor(appMetrics)
 	loggingInterceptor := interceptor.NewLoggingInterceptor(log)
 
-	gRPCServer := grpc.NewServer(
+	// The stats handler opens a server span per call, continuing the trace passed in the incoming metadata.
+	serverOptions := []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
+	var tlsReloader *tlsconfig.Reloader
+	stopReload := func() {}
+
+	if config.TLS.Enabled {
+		tlsReloader, err = tlsconfig.NewReloader(&config.TLS, log)
+		if err != nil {
+			return nil, fmt.Errorf("New: cannot initialize tls: %w", err)
+		}
+
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsReloader.TLSConfig())))
+
+		var ctx context.Context
+		ctx, stopReload = context.WithCancel(context.Background())
+
+		go tlsReloader.Run(ctx)
+	}
+
+	// Metrics wrap the whole chain, so rejected calls are counted too. Authentication goes next,
+	// so the concurrency limits are kept per principal and the tenant can be bound to the credentials.
+	serverOptions = append(serverOptions,
 		grpc.ChainUnaryInterceptor(
+			metricsInterceptor.Unary(),
+			authInterceptor.Unary(),
+			tenantInterceptor.Unary(),
 			concurrencyInterceptor.Unary(),
 			loggingInterceptor.Unary(),
 		),
 		grpc.ChainStreamInterceptor(
+			metricsInterceptor.Stream(),
+			authInterceptor.Stream(),
+			tenantInterceptor.Stream(),
 			concurrencyInterceptor.Stream(),
 			loggingInterceptor.StreamLoggingInterceptor(),
 		),
 	)
 
+	gRPCServer := grpc.NewServer(serverOptions...)
+
 	serviceConfig := &service.Config{
-		BufSize:       config.BufSize,
-		MaxLimit:      config.MaxLimit,
-		DefaultLimit:  config.DefaultLimit,
-		MaxOffset:     config.MaxOffset,
-		DefaultOffset: config.DefaultOffset,
-		Timeout:       config.OperationTimeout,
+		BufSize:          config.BufSize,
+		MaxLimit:         config.MaxLimit,
+		DefaultLimit:     config.DefaultLimit,
+		MaxOffset:        config.MaxOffset,
+		DefaultOffset:    config.DefaultOffset,
+		Timeout:          config.OperationTimeout,
+		TransferTimeout:  config.TransferTimeout,
+		PendingRefresh:   config.PendingRefresh,
+		UploadSessionTTL: config.UploadSessionTTL,
+		Dedup:            config.Dedup,
+		AdminGroups:      config.Auth.AdminGroups,
+		Quota:            &config.Quota,
+		LinkSigner:       links.NewSigner(&config.Links),
+		LinkTTL:          config.Links.DefaultTTL,
+		MaxLinkTTL:       config.Links.MaxTTL,
 	}
 
-	service.Register(gRPCServer, objectStorage, metaStorage, serviceConfig, log)
+	background := service.NewBackground()
+
+	service.Register(gRPCServer, objectStorage, metaStorage, serviceConfig, background, log)
+
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
+
+	prober := health.NewProber(healthServer,
+		[]health.Dependency{
+			{Name: "postgres", Check: metaStorage.Ping},
+			{Name: "minio", Check: objectStorage.Ping},
+		},
+		[]string{
+			fileservice.FileService_ServiceDesc.ServiceName,
+			fileservice.TenantService_ServiceDesc.ServiceName,
+		},
+		&config.Health,
+		log,
+	)
+
+	probeCtx, stopProbe := context.WithCancel(context.Background())
+	go prober.Run(probeCtx)
 
 	reflection.Register(gRPCServer)
 
 	return &App{
 		gRPCServer:       gRPCServer,
+		limiter:          lim,
+		tlsReloader:      tlsReloader,
+		linkHandler:      service.NewLinkHandler(objectStorage, metaStorage, serviceConfig, background, log),
+		background:       background,
+		stopReload:       stopReload,
+		healthServer:     healthServer,
+		stopProbe:        stopProbe,
+		drainDelay:       config.Health.DrainDelay,
 		host:             config.Host,
 		port:             config.Port,
 		operationTimeout: config.OperationTimeout,
 		logger:           log,
-	}
+	}, nil
 }
 
 func (a *App) Start() error {
@@ -85,7 +226,7 @@ func (a *App) Start() error {
 		return fmt.Errorf("Start: failed to create listen: %w", err)
 	}
 
-	a.logger.Info("Start: gRPC server is starting", zap.String("addr", addr))
+	a.logger.Info("Start: gRPC server is starting", zap.String("addr", addr), zap.Bool("tls", a.tlsReloader != nil))
 
 	err = a.gRPCServer.Serve(lis)
 	if err != nil {
@@ -96,6 +237,36 @@ func (a *App) Start() error {
 	return nil
 }
 
+// LinkHandler redeems the links created over gRPC, it is served by the HTTP server.
+func (a *App) LinkHandler() http.Handler {
+	return a.linkHandler
+}
+
+// SetBandwidth applies new bandwidth limits without restarting the server.
+func (a *App) SetBandwidth(config limiter.BandwidthConfig) {
+	a.limiter.SetBandwidth(config)
+	a.logger.Info("SetBandwidth: bandwidth limits changed",
+		zap.Int64("clientRate", config.ClientRate),
+		zap.Int64("totalRate", config.TotalRate),
+	)
+}
+
+// WaitBackground waits for the jobs left behind by the calls, the ones still running when ctx ends are canceled.
+// It is called after Stop, before the storages are closed.
+func (a *App) WaitBackground(ctx context.Context) {
+	a.background.Wait(ctx)
+}
+
+// Stop reports every service as NOT_SERVING first, so load balancers drain the instance before the calls are stopped.
 func (a *App) Stop() {
+	a.stopProbe()
+	a.healthServer.Shutdown()
+
+	if a.drainDelay > 0 {
+		a.logger.Info("Stop: draining", zap.Duration("delay", a.drainDelay))
+		time.Sleep(a.drainDelay)
+	}
+
 	a.gRPCServer.GracefulStop()
+	a.stopReload()
 }
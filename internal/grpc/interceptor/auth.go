@@ -0,0 +1,92 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/auth"
+)
+
+type AuthInterceptor struct {
+	authenticator *auth.Authenticator
+	logger        *zap.Logger
+}
+
+func NewAuthInterceptor(authenticator *auth.Authenticator, logger *zap.Logger) *AuthInterceptor {
+	return &AuthInterceptor{
+		authenticator: authenticator,
+		logger:        logger,
+	}
+}
+
+func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := ai.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (ai *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := ai.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate returns the context carrying the principal of the caller.
+// When authentication is not enforced a client certificate still identifies the caller.
+func (ai *AuthInterceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if !ai.authenticator.Enabled() || ai.authenticator.IsPublic(fullMethod) {
+		if principal, ok := auth.PrincipalFromPeer(ctx); ok {
+			return auth.NewContext(ctx, principal), nil
+		}
+
+		return ctx, nil
+	}
+
+	principal, err := ai.authenticator.Authenticate(ctx)
+	if err != nil {
+		ai.logger.Warn("Authentication failed", zap.String("method", fullMethod), zap.Error(err))
+
+		if errors.Is(err, auth.ErrNoCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "credentials are required")
+		}
+
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// contextStream replaces the context of a server stream, so handlers see values added by interceptors.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
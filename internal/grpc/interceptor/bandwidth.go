@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/limiter"
+)
+
+// throttledStream paces the file content passing through the stream to the bandwidth limits of the client.
+type throttledStream struct {
+	grpc.ServerStream
+	registry *limiter.Registry
+	limiter  *limiter.ClientLimiter
+}
+
+func (s *throttledStream) wait(n int) error {
+	err := s.registry.WaitBytes(s.Context(), s.limiter, n)
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return nil
+}
+
+// RecvMsg waits after the chunk is received, so the next one is read from the client no sooner than the limits allow.
+func (s *throttledStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	if msg, ok := m.(chunkMessage); ok {
+		return s.wait(len(msg.GetChunk()))
+	}
+
+	return nil
+}
+
+func (s *throttledStream) SendMsg(m interface{}) error {
+	if msg, ok := m.(chunkMessage); ok {
+		err := s.wait(len(msg.GetChunk()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,89 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type RequestObserver interface {
+	ObserveRequest(method string, code string, duration time.Duration)
+	AddReceivedBytes(method string, n int)
+	AddSentBytes(method string, n int)
+}
+
+// chunkMessage is implemented by the messages carrying file content.
+type chunkMessage interface {
+	GetChunk() []byte
+}
+
+type MetricsInterceptor struct {
+	observer RequestObserver
+}
+
+func NewMetricsInterceptor(observer RequestObserver) *MetricsInterceptor {
+	return &MetricsInterceptor{observer: observer}
+}
+
+func (mi *MetricsInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		mi.observer.ObserveRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+func (mi *MetricsInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, &meteredStream{ServerStream: ss, method: info.FullMethod, observer: mi.observer})
+
+		mi.observer.ObserveRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// meteredStream counts the file content passing through the stream.
+type meteredStream struct {
+	grpc.ServerStream
+	method   string
+	observer RequestObserver
+}
+
+func (s *meteredStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		if msg, ok := m.(chunkMessage); ok {
+			s.observer.AddReceivedBytes(s.method, len(msg.GetChunk()))
+		}
+	}
+
+	return err
+}
+
+func (s *meteredStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		if msg, ok := m.(chunkMessage); ok {
+			s.observer.AddSentBytes(s.method, len(msg.GetChunk()))
+		}
+	}
+
+	return err
+}
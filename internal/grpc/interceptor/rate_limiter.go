@@ -2,14 +2,20 @@ package interceptor
 
 import (
 	"context"
+	"math"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"fileservice/internal/auth"
 	"fileservice/internal/limiter"
 )
 
@@ -19,6 +25,8 @@ type ConcurrencyInterceptor struct {
 	OnAcquire func(method, clientID, kind string)
 	OnRelease func(method, clientID, kind string)
 	OnReject  func(method, clientID, kind string)
+	// OnRateLimit is called when a request is rejected by the token bucket rather than the concurrency limit.
+	OnRateLimit func(method, clientID, kind string)
 }
 
 func NewConcurrencyInterceptor(registry *limiter.Registry, logger *zap.Logger) *ConcurrencyInterceptor {
@@ -29,21 +37,80 @@ func NewConcurrencyInterceptor(registry *limiter.Registry, logger *zap.Logger) *
 
 }
 
+// retryAfterKey carries the number of seconds the client should wait before the next attempt.
+const retryAfterKey = "retry-after"
+
+// allow takes a token from the bucket of the method kind. A rejected request gets ResourceExhausted
+// and the retry-after trailer.
+func (ci *ConcurrencyInterceptor) allow(lim *limiter.ClientLimiter, method string, clientID string, kind string) (metadata.MD, error) {
+	var ok bool
+	var delay time.Duration
+
+	switch kind {
+	case "list":
+		ok, delay = lim.AllowList()
+	default:
+		ok, delay = lim.AllowUpload()
+	}
+
+	if ok {
+		return nil, nil
+	}
+
+	if ci.OnRateLimit != nil {
+		go ci.OnRateLimit(method, clientID, kind)
+	}
+
+	// A zero delay means the burst is smaller than one request, so waiting does not help. One second is still reported.
+	retryAfter := int64(math.Ceil(delay.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	ci.logger.Warn("Too many requests",
+		zap.String("clientID", clientID),
+		zap.String("method", method),
+		zap.String("kind", kind),
+		zap.Int64("retryAfter", retryAfter),
+	)
+
+	trailer := metadata.Pairs(retryAfterKey, strconv.FormatInt(retryAfter, 10))
+
+	return trailer, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %ds", retryAfter)
+}
+
+// unlimitedServices are called by orchestrators and tooling. They skip the limits of the client, so the probes keep
+// answering under load and a long Watch does not hold one of its slots.
+var unlimitedServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// methodKind returns the limits the method falls under, an empty kind is not limited.
 func methodKind(fullMethod string) string {
+	for _, prefix := range unlimitedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return ""
+		}
+	}
+
 	switch fullMethod {
-	case "/file_service.FileService/ListFiles":
+	case "/file_service.FileService/ListFiles",
+		"/file_service.FileService/ListTrash":
 		return "list"
 
-	case "/file_service.FileService/UploadFile",
-		"/file_service.FileService/GetFile":
-
-		return "load"
 	default:
 		return "load"
 	}
 }
 
+// getId identifies the client by the authenticated principal and falls back to the peer address.
 func (ci *ConcurrencyInterceptor) getId(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return "principal:" + principal.Subject
+	}
+
 	p, ok := peer.FromContext(ctx)
 	if ok && p.Addr != nil {
 		host, _, err := net.SplitHostPort(p.Addr.String())
@@ -64,10 +131,20 @@ func (ci *ConcurrencyInterceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
+		kind := methodKind(info.FullMethod)
+		if kind == "" {
+			return handler(ctx, req)
+		}
+
 		clientID := ci.getId(ctx)
 		lim := ci.registry.Get(clientID)
 
-		kind := methodKind(info.FullMethod)
+		trailer, err := ci.allow(lim, info.FullMethod, clientID, kind)
+		if err != nil {
+			_ = grpc.SetTrailer(ctx, trailer)
+			return nil, err
+		}
+
 		switch kind {
 		case "list":
 			if !lim.AcquireList() {
@@ -133,10 +210,20 @@ func (ci *ConcurrencyInterceptor) Stream() grpc.StreamServerInterceptor {
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
+		kind := methodKind(info.FullMethod)
+		if kind == "" {
+			return handler(srv, ss)
+		}
+
 		clientID := ci.getId(ss.Context())
 		lim := ci.registry.Get(clientID)
 
-		kind := methodKind(info.FullMethod)
+		trailer, err := ci.allow(lim, info.FullMethod, clientID, kind)
+		if err != nil {
+			ss.SetTrailer(trailer)
+			return err
+		}
+
 		switch kind {
 		case "list":
 			if !lim.AcquireList() {
@@ -191,6 +278,6 @@ func (ci *ConcurrencyInterceptor) Stream() grpc.StreamServerInterceptor {
 			}()
 		}
 
-		return handler(srv, ss)
+		return handler(srv, &throttledStream{ServerStream: ss, registry: ci.registry, limiter: lim})
 	}
 }
@@ -0,0 +1,257 @@
+package interceptor
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/limiter"
+)
+
+const (
+	listMethod   = "/file_service.FileService/ListFiles"
+	uploadMethod = "/file_service.FileService/UploadFile"
+	checkMethod  = "/grpc.health.v1.Health/Check"
+	watchMethod  = "/grpc.health.v1.Health/Watch"
+)
+
+// transportStream records the trailer set by grpc.SetTrailer in unary calls.
+type transportStream struct {
+	method  string
+	trailer metadata.MD
+}
+
+func (s *transportStream) Method() string               { return s.method }
+func (s *transportStream) SetHeader(metadata.MD) error  { return nil }
+func (s *transportStream) SendHeader(metadata.MD) error { return nil }
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// serverStream records the trailer of a streaming call.
+type serverStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (s *serverStream) Context() context.Context  { return s.ctx }
+func (s *serverStream) SetTrailer(md metadata.MD) { s.trailer = metadata.Join(s.trailer, md) }
+
+func newTestInterceptor(t *testing.T, config *limiter.Config) *ConcurrencyInterceptor {
+	t.Helper()
+
+	config.TTL = time.Minute
+	registry := limiter.NewRegistry(config)
+	t.Cleanup(registry.Close)
+
+	return NewConcurrencyInterceptor(registry, zap.NewNop())
+}
+
+func okHandler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func checkRetryAfter(t *testing.T, trailer metadata.MD) {
+	t.Helper()
+
+	values := trailer.Get(retryAfterKey)
+	if len(values) != 1 {
+		t.Fatalf("retry-after trailer = %v, want one value", values)
+	}
+
+	seconds, err := strconv.Atoi(values[0])
+	if err != nil || seconds < 1 {
+		t.Errorf("retry-after = %q, want a positive number of seconds", values[0])
+	}
+}
+
+func TestUnaryRateLimit(t *testing.T) {
+	ci := newTestInterceptor(t, &limiter.Config{LoadConcurrent: 10, ReadConcurrent: 10, ReadRate: 0.5, ReadBurst: 2})
+
+	var rateLimited []string
+	done := make(chan struct{}, 1)
+	ci.OnRateLimit = func(method, clientID, kind string) {
+		rateLimited = append(rateLimited, kind)
+		done <- struct{}{}
+	}
+
+	unary := ci.Unary()
+	info := &grpc.UnaryServerInfo{FullMethod: listMethod}
+
+	for i := 0; i < 2; i++ {
+		ts := &transportStream{method: listMethod}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+
+		_, err := unary(ctx, nil, info, okHandler)
+		if err != nil {
+			t.Fatalf("request %d within the burst: error = %v", i, err)
+		}
+		if ts.trailer.Len() != 0 {
+			t.Errorf("request %d within the burst: trailer = %v, want none", i, ts.trailer)
+		}
+	}
+
+	ts := &transportStream{method: listMethod}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+
+	_, err := unary(ctx, nil, info, okHandler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("request over the rate: code = %v, want ResourceExhausted", status.Code(err))
+	}
+
+	checkRetryAfter(t, ts.trailer)
+
+	// At half a request per second the next token is about two seconds away.
+	if got := ts.trailer.Get(retryAfterKey)[0]; got != "2" {
+		t.Errorf("retry-after = %s, want 2", got)
+	}
+
+	<-done
+	if len(rateLimited) != 1 || rateLimited[0] != "list" {
+		t.Errorf("OnRateLimit kinds = %v, want [list]", rateLimited)
+	}
+
+	// The read rate does not affect the other kind.
+	_, err = unary(grpc.NewContextWithServerTransportStream(context.Background(), &transportStream{}), nil,
+		&grpc.UnaryServerInfo{FullMethod: uploadMethod}, okHandler)
+	if err != nil {
+		t.Errorf("upload after the list rate is spent: error = %v", err)
+	}
+}
+
+func TestStreamRateLimit(t *testing.T) {
+	ci := newTestInterceptor(t, &limiter.Config{LoadConcurrent: 10, ReadConcurrent: 10, LoadRate: 1, LoadBurst: 1})
+
+	stream := ci.Stream()
+	info := &grpc.StreamServerInfo{FullMethod: uploadMethod}
+	handler := func(interface{}, grpc.ServerStream) error { return nil }
+
+	first := &serverStream{ctx: context.Background()}
+	err := stream(nil, first, info, handler)
+	if err != nil {
+		t.Fatalf("first stream: error = %v", err)
+	}
+
+	second := &serverStream{ctx: context.Background()}
+	err = stream(nil, second, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("stream over the rate: code = %v, want ResourceExhausted", status.Code(err))
+	}
+
+	checkRetryAfter(t, second.trailer)
+}
+
+func TestMethodKind(t *testing.T) {
+	tests := map[string]string{
+		"/file_service.FileService/ListFiles":                       "list",
+		"/file_service.FileService/ListTrash":                       "list",
+		"/file_service.FileService/UploadFile":                      "load",
+		"/file_service.FileService/GetFile":                         "load",
+		"/file_service.FileService/DeleteFile":                      "load",
+		"/grpc.health.v1.Health/Check":                              "",
+		"/grpc.health.v1.Health/Watch":                              "",
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo": "",
+	}
+
+	for method, want := range tests {
+		if got := methodKind(method); got != want {
+			t.Errorf("methodKind(%q) = %q, want %q", method, got, want)
+		}
+	}
+}
+
+// TestHealthSkipsConcurrencyLimit keeps the probes answering while the uploads of the client take every slot,
+// and a running Watch does not take a slot from the uploads.
+func TestHealthSkipsConcurrencyLimit(t *testing.T) {
+	ci := newTestInterceptor(t, &limiter.Config{LoadConcurrent: 1, ReadConcurrent: 1})
+
+	stream := ci.Stream()
+	unary := ci.Unary()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func(interface{}, grpc.ServerStream) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- stream(nil, &serverStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: uploadMethod}, blocking)
+	}()
+	<-started
+
+	_, err := unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: checkMethod}, okHandler)
+	if err != nil {
+		t.Errorf("Check while the upload slot is taken: error = %v", err)
+	}
+
+	close(release)
+	if err = <-done; err != nil {
+		t.Fatalf("upload stream: error = %v", err)
+	}
+
+	release = make(chan struct{})
+	go func() {
+		done <- stream(nil, &serverStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: watchMethod}, blocking)
+	}()
+	<-started
+
+	_, err = unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: uploadMethod}, okHandler)
+	if err != nil {
+		t.Errorf("upload while Watch is running: error = %v", err)
+	}
+
+	close(release)
+	if err = <-done; err != nil {
+		t.Fatalf("Watch stream: error = %v", err)
+	}
+}
+
+// TestHealthSkipsRateLimit checks the probes neither spend the load tokens of the client nor get rejected once they are spent.
+func TestHealthSkipsRateLimit(t *testing.T) {
+	ci := newTestInterceptor(t, &limiter.Config{LoadConcurrent: 10, ReadConcurrent: 10, LoadRate: 0.5, LoadBurst: 1})
+
+	unary := ci.Unary()
+	stream := ci.Stream()
+
+	for i := 0; i < 5; i++ {
+		ts := &transportStream{method: checkMethod}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: checkMethod}, okHandler)
+		if err != nil {
+			t.Fatalf("Check %d: error = %v", i, err)
+		}
+		if ts.trailer.Len() != 0 {
+			t.Errorf("Check %d: trailer = %v, want none", i, ts.trailer)
+		}
+	}
+
+	// The single load token is still there after the probes.
+	_, err := unary(grpc.NewContextWithServerTransportStream(context.Background(), &transportStream{}), nil,
+		&grpc.UnaryServerInfo{FullMethod: uploadMethod}, okHandler)
+	if err != nil {
+		t.Fatalf("upload after the probes: error = %v", err)
+	}
+
+	// With the token spent the probes are still served.
+	watch := &serverStream{ctx: context.Background()}
+	err = stream(nil, watch, &grpc.StreamServerInfo{FullMethod: watchMethod}, func(interface{}, grpc.ServerStream) error { return nil })
+	if err != nil {
+		t.Errorf("Watch after the load rate is spent: error = %v", err)
+	}
+	if watch.trailer.Len() != 0 {
+		t.Errorf("Watch trailer = %v, want none", watch.trailer)
+	}
+}
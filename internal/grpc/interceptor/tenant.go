@@ -0,0 +1,121 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/auth"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// tenantServicePrefix covers the calls working with files, admin and infrastructure calls do not belong to a tenant.
+const tenantServicePrefix = "/file_service.FileService/"
+
+type TenantStore interface {
+	GetTenant(ctx context.Context, id string) (*postgres.Tenant, error)
+}
+
+type TenantInterceptor struct {
+	store  TenantStore
+	logger *zap.Logger
+}
+
+func NewTenantInterceptor(store TenantStore, logger *zap.Logger) *TenantInterceptor {
+	return &TenantInterceptor{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (ti *TenantInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := ti.resolve(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (ti *TenantInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := ti.resolve(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// resolve attaches the tenant of the call. Credentials bound to a tenant win, otherwise the tenant is taken
+// from the request metadata and the default tenant is used when none is given.
+func (ti *TenantInterceptor) resolve(ctx context.Context, fullMethod string) (context.Context, error) {
+	if !strings.HasPrefix(fullMethod, tenantServicePrefix) {
+		return ctx, nil
+	}
+
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenant.MetadataKey); len(values) > 0 {
+			requested = values[0]
+		}
+	}
+
+	id := tenant.Default
+
+	principal, ok := auth.FromContext(ctx)
+	switch {
+	case ok && principal.Tenant != "":
+		if requested != "" && requested != principal.Tenant {
+			ti.logger.Warn("Tenant does not match credentials",
+				zap.String("method", fullMethod),
+				zap.String("subject", principal.Subject),
+				zap.String("tenant", requested),
+			)
+			return nil, status.Error(codes.PermissionDenied, "tenant does not match credentials")
+		}
+
+		id = principal.Tenant
+
+	case requested != "":
+		id = requested
+	}
+
+	t, err := ti.store.GetTenant(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			ti.logger.Warn("Unknown tenant", zap.String("method", fullMethod), zap.String("tenant", id))
+			return nil, status.Error(codes.PermissionDenied, "unknown tenant")
+		}
+
+		ti.logger.Error("Failed to get tenant", zap.String("method", fullMethod), zap.String("tenant", id), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to resolve tenant")
+	}
+
+	if t.Disabled {
+		ti.logger.Warn("Tenant is disabled", zap.String("method", fullMethod), zap.String("tenant", id))
+		return nil, status.Error(codes.PermissionDenied, "tenant is disabled")
+	}
+
+	return tenant.NewContext(ctx, id), nil
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/auth"
+	"fileservice/internal/sorage/postgres"
+)
+
+// accessor returns the authenticated caller, nil means the call is not authenticated and access is not checked.
+func accessor(ctx context.Context) *postgres.Accessor {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.Subject == "" {
+		return nil
+	}
+
+	return &postgres.Accessor{Subject: principal.Subject, Groups: principal.Groups}
+}
+
+// owner is the owner recorded for the files and upload sessions created by the call.
+func owner(ctx context.Context) string {
+	if a := accessor(ctx); a != nil {
+		return a.Subject
+	}
+
+	return ""
+}
+
+// authorize checks the permission of the caller on the file and returns a status error when it is missing.
+// The owner has every permission and files without an owner are open to everyone.
+func (s *service) authorize(ctx context.Context, method string, file *postgres.File, permission string) error {
+	a := accessor(ctx)
+	fileOwner := file.Info.GetOwner()
+	if a == nil || fileOwner == "" || fileOwner == a.Subject {
+		return nil
+	}
+
+	id := file.Info.GetId()
+
+	allowed, err := s.metaStorage.HasPermission(ctx, id, a, permission)
+	if err != nil {
+		s.logger.Error(method+": failed to check access", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to check access: %s", id)
+	}
+
+	if !allowed {
+		s.logger.Warn(method+": permission denied",
+			zap.String("id", id),
+			zap.String("subject", a.Subject),
+			zap.String("permission", permission),
+		)
+		return status.Errorf(codes.PermissionDenied, "%s permission is required", permission)
+	}
+
+	return nil
+}
+
+// authorizeFile loads the file and checks the permission, it is used by the calls which do not need the file otherwise.
+func (s *service) authorizeFile(ctx context.Context, method string, id string, permission string) error {
+	file, err := s.lookupFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn(method+": file not found", zap.String("id", id))
+			return status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error(method+": failed to get file info", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	return s.authorize(ctx, method, file, permission)
+}
+
+// authorizeOwner allows managing the acl to the owner only, unauthenticated calls are not checked.
+func (s *service) authorizeOwner(ctx context.Context, method string, file *postgres.File) error {
+	a := accessor(ctx)
+	if a == nil || file.Info.GetOwner() == a.Subject {
+		return nil
+	}
+
+	s.logger.Warn(method+": caller is not the owner", zap.String("id", file.Info.GetId()), zap.String("subject", a.Subject))
+	return status.Error(codes.PermissionDenied, "only the owner can manage access")
+}
+
+func (s *service) GrantAccess(ctx context.Context, req *fileservice.GrantAccessRequest) (*fileservice.GrantAccessResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+
+	granteeType, permissions, err := s.parseAccessRequest("GrantAccess", id, req.GetGranteeType(), req.GetGrantee(), req.GetPermissions())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permissions) == 0 {
+		s.logger.Warn("GrantAccess: permissions are empty", zap.String("id", id))
+		return nil, status.Error(codes.InvalidArgument, "at least one permission is required")
+	}
+
+	err = s.authorizeAccessManagement(ctx, "GrantAccess", id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.metaStorage.GrantAccess(ctx, id, granteeType, req.GetGrantee(), permissions)
+	if err != nil {
+		s.logger.Error("GrantAccess: failed to grant access", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to grant access: %s", id)
+	}
+
+	s.logger.Info("GrantAccess: successfully granted access", zap.String("id", id), zap.String("grantee", req.GetGrantee()))
+	return &fileservice.GrantAccessResponse{}, nil
+}
+
+func (s *service) RevokeAccess(ctx context.Context, req *fileservice.RevokeAccessRequest) (*fileservice.RevokeAccessResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+
+	granteeType, permissions, err := s.parseAccessRequest("RevokeAccess", id, req.GetGranteeType(), req.GetGrantee(), req.GetPermissions())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.authorizeAccessManagement(ctx, "RevokeAccess", id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.metaStorage.RevokeAccess(ctx, id, granteeType, req.GetGrantee(), permissions)
+	if err != nil {
+		s.logger.Error("RevokeAccess: failed to revoke access", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to revoke access: %s", id)
+	}
+
+	s.logger.Info("RevokeAccess: successfully revoked access", zap.String("id", id), zap.String("grantee", req.GetGrantee()))
+	return &fileservice.RevokeAccessResponse{}, nil
+}
+
+func (s *service) ListAccess(ctx context.Context, req *fileservice.ListAccessRequest) (*fileservice.ListAccessResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("ListAccess: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("ListAccess: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("ListAccess: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorizeOwner(ctx, "ListAccess", file)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.metaStorage.ListAccess(ctx, id)
+	if err != nil {
+		s.logger.Error("ListAccess: failed to list acl", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list access: %s", id)
+	}
+
+	resp := &fileservice.ListAccessResponse{
+		Owner:   file.Info.GetOwner(),
+		Entries: make([]*fileservice.AccessEntry, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, accessEntryToProto(entry))
+	}
+
+	s.logger.Info("ListAccess: successfully listed access", zap.String("id", id), zap.Int("count", len(entries)))
+	return resp, nil
+}
+
+func (s *service) authorizeAccessManagement(ctx context.Context, method string, id string) error {
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn(method+": file not found", zap.String("id", id))
+			return status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error(method+": failed to get file info", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	return s.authorizeOwner(ctx, method, file)
+}
+
+// parseAccessRequest validates the common fields of GrantAccess and RevokeAccess and maps them to the stored values.
+func (s *service) parseAccessRequest(
+	method string,
+	id string,
+	granteeType fileservice.GranteeType,
+	grantee string,
+	permissions []fileservice.Permission,
+) (string, []string, error) {
+	if id == "" {
+		s.logger.Warn(method + ": file id is empty")
+		return "", nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	if grantee == "" {
+		s.logger.Warn(method+": grantee is empty", zap.String("id", id))
+		return "", nil, status.Error(codes.InvalidArgument, "grantee is required")
+	}
+
+	var storedType string
+	switch granteeType {
+	case fileservice.GranteeType_GRANTEE_TYPE_USER:
+		storedType = postgres.GranteeUser
+	case fileservice.GranteeType_GRANTEE_TYPE_GROUP:
+		storedType = postgres.GranteeGroup
+	default:
+		s.logger.Warn(method+": unknown grantee type", zap.String("id", id), zap.Int32("grantee_type", int32(granteeType)))
+		return "", nil, status.Error(codes.InvalidArgument, "unknown grantee type")
+	}
+
+	stored := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		name, ok := permissionNames[permission]
+		if !ok {
+			s.logger.Warn(method+": unknown permission", zap.String("id", id), zap.Int32("permission", int32(permission)))
+			return "", nil, status.Errorf(codes.InvalidArgument, "unknown permission: %s", permission)
+		}
+
+		stored = append(stored, name)
+	}
+
+	return storedType, stored, nil
+}
+
+var permissionNames = map[fileservice.Permission]string{
+	fileservice.Permission_PERMISSION_READ:   postgres.PermissionRead,
+	fileservice.Permission_PERMISSION_WRITE:  postgres.PermissionWrite,
+	fileservice.Permission_PERMISSION_DELETE: postgres.PermissionDelete,
+}
+
+func accessEntryToProto(entry *postgres.AccessEntry) *fileservice.AccessEntry {
+	resp := &fileservice.AccessEntry{
+		Grantee:   entry.Grantee,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+
+	if entry.GranteeType == postgres.GranteeGroup {
+		resp.GranteeType = fileservice.GranteeType_GRANTEE_TYPE_GROUP
+	}
+
+	for permission, name := range permissionNames {
+		if name == entry.Permission {
+			resp.Permission = permission
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// Background runs the jobs the calls leave behind, like hashing an object assembled from parts.
+// The server waits for them on shutdown, so none of them uses the storages after they are closed.
+type Background struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBackground() *Background {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Background{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go runs the job with the values of the call context, it is only canceled by Wait.
+func (b *Background) Go(ctx context.Context, job func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(b.ctx, cancel)
+
+	b.wg.Go(func() {
+		defer cancel()
+		defer stop()
+
+		job(ctx)
+	})
+}
+
+// Wait lets the running jobs finish until ctx ends, then cancels the rest and waits for them to return.
+// It is called once no new calls are served.
+func (b *Background) Wait(ctx context.Context) {
+	done := make(chan struct{})
+
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+
+	b.cancel()
+	<-done
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fileservice/internal/tenant"
+)
+
+func TestBackgroundWaitsForJobs(t *testing.T) {
+	b := NewBackground()
+
+	callCtx, cancelCall := context.WithCancel(tenant.NewContext(context.Background(), "team-a"))
+
+	finished := make(chan string, 1)
+	b.Go(callCtx, func(ctx context.Context) {
+		time.Sleep(20 * time.Millisecond)
+
+		if ctx.Err() != nil {
+			finished <- "canceled"
+			return
+		}
+
+		finished <- tenant.FromContext(ctx)
+	})
+
+	// The job outlives its call.
+	cancelCall()
+
+	b.Wait(context.Background())
+
+	select {
+	case got := <-finished:
+		if got != "team-a" {
+			t.Errorf("job finished with %q, want the tenant of the call", got)
+		}
+	default:
+		t.Fatal("Wait() returned before the job finished")
+	}
+}
+
+func TestBackgroundWaitCancelsJobs(t *testing.T) {
+	b := NewBackground()
+
+	b.Go(context.Background(), func(ctx context.Context) {
+		<-ctx.Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	b.Wait(ctx)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait() took %v, want the job canceled after the deadline", elapsed)
+	}
+}
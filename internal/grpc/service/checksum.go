@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// digest computes all checksums stored for a file in a single pass over its content.
+type digest struct {
+	sha256 hash.Hash
+	crc32c hash.Hash32
+}
+
+func newDigest() *digest {
+	return &digest{
+		sha256: sha256.New(),
+		crc32c: crc32.New(castagnoliTable),
+	}
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	d.sha256.Write(p)
+	d.crc32c.Write(p)
+
+	return len(p), nil
+}
+
+func (d *digest) SHA256() string {
+	return hex.EncodeToString(d.sha256.Sum(nil))
+}
+
+func (d *digest) CRC32C() string {
+	return hex.EncodeToString(binary.BigEndian.AppendUint32(nil, d.crc32c.Sum32()))
+}
+
+// matches reports whether the checksums declared by a client agree with the computed ones, empty values are not checked.
+func (d *digest) matches(declaredSHA256 string, declaredCRC32C string) bool {
+	return (declaredSHA256 == "" || strings.EqualFold(declaredSHA256, d.SHA256())) &&
+		(declaredCRC32C == "" || strings.EqualFold(declaredCRC32C, d.CRC32C()))
+}
+
+func (s *service) hashObject(ctx context.Context, id string) (*digest, error) {
+	object, err := s.objectStorage.GetObject(ctx, id, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("hashObject: failed to get object: %w", err)
+	}
+	defer object.Close()
+
+	d := newDigest()
+
+	_, err = io.Copy(d, object)
+	if err != nil {
+		return nil, fmt.Errorf("hashObject: failed to read object: %w", err)
+	}
+
+	return d, nil
+}
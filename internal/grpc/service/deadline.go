@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+)
+
+// operationContext bounds the metadata calls of a streaming call. Each phase of the call takes a context of its own,
+// so the time spent on the transfer does not eat into the deadline of the calls that follow it.
+func (s *service) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.config.Timeout)
+}
+
+// transferContext bounds the streaming of the file content. It lasts as long as the client keeps the stream open,
+// unless the transfer timeout is configured.
+func (s *service) transferContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.config.TransferTimeout > 0 {
+		return context.WithTimeout(ctx, s.config.TransferTimeout)
+	}
+
+	return context.WithCancel(ctx)
+}
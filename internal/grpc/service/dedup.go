@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/tenant"
+)
+
+// storeBlob moves the object uploaded for the file into the content addressed blob shared by identical files.
+// On error the file keeps its own object, so the upload stays usable without deduplication.
+func (s *service) storeBlob(ctx context.Context, id string, key string, d *digest, size int64) error {
+	hash, err := s.acquireBlobObject(ctx, key, d, size)
+	if err != nil {
+		return fmt.Errorf("storeBlob: %w", err)
+	}
+
+	err = s.metaStorage.AttachBlob(ctx, id, key, hash, minio.BlobKey(hash))
+	if err != nil {
+		s.releaseBlob(ctx, hash)
+		return fmt.Errorf("storeBlob: %w", err)
+	}
+
+	s.removeUploadedObject(ctx, key)
+
+	s.logger.Info("storeBlob: file stored as blob", zap.String("id", id), zap.String("hash", hash))
+	return nil
+}
+
+// acquireBlobObject takes a reference to the blob with the content of the uploaded object and makes sure the blob object exists.
+// The caller owns the reference and has to release it if the blob is not attached in the end.
+// Blobs are shared within the tenant of the call only.
+func (s *service) acquireBlobObject(ctx context.Context, key string, d *digest, size int64) (string, error) {
+	hash := tenant.BlobHash(tenant.FromContext(ctx), d.SHA256())
+
+	refCount, err := s.metaStorage.AcquireBlob(ctx, hash, size)
+	if err != nil {
+		return "", fmt.Errorf("acquireBlobObject: failed to acquire blob: %w", err)
+	}
+
+	err = s.ensureBlobObject(ctx, key, minio.BlobKey(hash), refCount)
+	if err != nil {
+		s.releaseBlob(ctx, hash)
+		return "", fmt.Errorf("acquireBlobObject: %w", err)
+	}
+
+	return hash, nil
+}
+
+// removeUploadedObject drops the object the content was uploaded to once it has been copied into a blob.
+func (s *service) removeUploadedObject(ctx context.Context, key string) {
+	err := s.objectStorage.RemoveObject(ctx, key)
+	if err != nil {
+		s.logger.Warn("removeUploadedObject: failed to remove uploaded object", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// ensureBlobObject copies the uploaded object into the blob unless another file has already done it.
+func (s *service) ensureBlobObject(ctx context.Context, id string, key string, refCount int64) error {
+	if refCount > 1 {
+		_, err := s.objectStorage.StatObject(ctx, key)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, minio.ErrNotFound) {
+			return fmt.Errorf("ensureBlobObject: failed to stat blob: %w", err)
+		}
+	}
+
+	err := s.objectStorage.CopyObject(ctx, id, key)
+	if err != nil {
+		return fmt.Errorf("ensureBlobObject: failed to copy blob: %w", err)
+	}
+
+	return nil
+}
+
+// releaseBlob drops a reference to the blob and removes its object once nobody uses it.
+func (s *service) releaseBlob(ctx context.Context, hash string) {
+	last, err := s.metaStorage.ReleaseBlob(ctx, hash)
+	if err != nil {
+		s.logger.Error("releaseBlob: failed to release blob", zap.String("hash", hash), zap.Error(err))
+		return
+	}
+
+	if last {
+		s.removeBlobObject(ctx, hash)
+	}
+}
+
+func (s *service) removeBlobObject(ctx context.Context, hash string) {
+	err := s.objectStorage.RemoveObject(ctx, minio.BlobKey(hash))
+	if err != nil {
+		s.logger.Warn("removeBlobObject: failed to remove blob object", zap.String("hash", hash), zap.Error(err))
+	}
+}
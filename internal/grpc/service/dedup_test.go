@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/tenant"
+)
+
+func TestDedupSharesBlob(t *testing.T) {
+	s, objects, meta := newTestService(t, &Config{Dedup: true})
+	ctx := context.Background()
+
+	content := []byte("identical content")
+
+	first, err := uploadFile(ctx, s, "first.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	second, err := uploadFile(ctx, s, "second.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	hash := tenant.BlobHash(tenant.Default, newDigestOf(content).SHA256())
+	blobKey := minio.BlobKey(hash)
+	if keys := objects.keys(); len(keys) != 1 || keys[0] != blobKey {
+		t.Fatalf("objects = %v, want only the blob %q", keys, blobKey)
+	}
+
+	if refs := meta.blobs[hash]; refs != 2 {
+		t.Errorf("blob references = %d, want 2", refs)
+	}
+
+	_, err = s.DeleteFile(ctx, &fileservice.DeleteFileRequest{FileId: first})
+	if err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+
+	// The blob stays while the other file refers to it.
+	got, err := readFile(ctx, s, second, 0)
+	if err != nil {
+		t.Fatalf("GetFile() after deleting the other file error = %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("GetFile() = %q, want %q", got, content)
+	}
+
+	_, err = s.DeleteFile(ctx, &fileservice.DeleteFileRequest{FileId: second})
+	if err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+
+	if keys := objects.keys(); len(keys) != 0 {
+		t.Errorf("objects after deleting both files = %v, want none", keys)
+	}
+	if len(meta.blobs) != 0 {
+		t.Errorf("blobs after deleting both files = %v, want none", meta.blobs)
+	}
+}
+
+func TestDedupOff(t *testing.T) {
+	s, objects, _ := newTestService(t, &Config{})
+	ctx := context.Background()
+
+	for _, name := range []string{"first.txt", "second.txt"} {
+		_, err := uploadFile(ctx, s, name, []byte("identical content"))
+		if err != nil {
+			t.Fatalf("UploadFile() error = %v", err)
+		}
+	}
+
+	if keys := objects.keys(); len(keys) != 2 {
+		t.Errorf("objects = %v, want one per file", keys)
+	}
+}
+
+func TestDedupScopedToTenant(t *testing.T) {
+	s, objects, _ := newTestService(t, &Config{Dedup: true})
+
+	for _, id := range []string{tenant.Default, "team-a"} {
+		_, err := uploadFile(tenant.NewContext(context.Background(), id), s, "report.txt", []byte("identical content"))
+		if err != nil {
+			t.Fatalf("UploadFile(%s) error = %v", id, err)
+		}
+	}
+
+	if keys := objects.keys(); len(keys) != 2 {
+		t.Errorf("objects = %v, want a blob per tenant", keys)
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/cleanup"
+	"fileservice/internal/sorage/postgres"
+)
+
+// DeleteFile removes the file in two steps. The row is first marked as deleting, so a crash
+// between removing the object and the row leaves a visible trace, and a repeated call finishes the job.
+func (s *service) DeleteFile(ctx context.Context, req *fileservice.DeleteFileRequest) (*fileservice.DeleteFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("DeleteFile: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	file, err := s.lookupFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("DeleteFile: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("DeleteFile: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete file: %s", id)
+	}
+
+	err = s.authorize(ctx, "DeleteFile", file, postgres.PermissionDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.metaStorage.MarkDeleting(ctx, id, postgres.StatusSuccess, postgres.StatusTrashed)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("DeleteFile: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("DeleteFile: failed to mark file as deleting", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete file: %s", id)
+	}
+
+	err = cleanup.RemoveFile(ctx, s.objectStorage, s.metaStorage, file, s.logger)
+	if err != nil {
+		s.logger.Error("DeleteFile: failed to delete file", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete file: %s", id)
+	}
+
+	s.logger.Info("DeleteFile: successfully deleted file", zap.String("id", id))
+	return &fileservice.DeleteFileResponse{}, nil
+}
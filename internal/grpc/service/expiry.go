@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// getFile returns the file info and treats an expired file as a missing one, even before the expiry worker removes it.
+func (s *service) getFile(ctx context.Context, id string) (*postgres.File, error) {
+	file, err := s.lookupFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if expired(file.Info.GetExpiresAt(), time.Now().UTC()) {
+		return nil, fmt.Errorf("getFile: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	return file, nil
+}
+
+// lookupFile returns the file of the tenant of the call, files of other tenants are reported as missing.
+func (s *service) lookupFile(ctx context.Context, id string) (*postgres.File, error) {
+	file, err := s.metaStorage.GetFileInfo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Tenant != tenant.FromContext(ctx) {
+		return nil, fmt.Errorf("lookupFile: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	return file, nil
+}
+
+func expired(expiresAt *timestamppb.Timestamp, now time.Time) bool {
+	return expiresAt != nil && !expiresAt.AsTime().After(now)
+}
+
+// expiryTime resolves the expiration of an upload, either given as a moment or as a ttl, nil means the file never expires.
+func expiryTime(expiresAt *timestamppb.Timestamp, ttl *durationpb.Duration, now time.Time) (*time.Time, string) {
+	switch {
+	case expiresAt != nil && ttl != nil:
+		return nil, "expiresAt and ttl cannot be combined"
+
+	case expiresAt != nil:
+		if !expiresAt.IsValid() {
+			return nil, "invalid expiresAt"
+		}
+
+		t := expiresAt.AsTime()
+		if !t.After(now) {
+			return nil, "expiresAt must be in the future"
+		}
+
+		return &t, ""
+
+	case ttl != nil:
+		if !ttl.IsValid() || ttl.AsDuration() <= 0 {
+			return nil, "ttl must be positive"
+		}
+
+		t := now.Add(ttl.AsDuration())
+		return &t, ""
+	}
+
+	return nil, ""
+}
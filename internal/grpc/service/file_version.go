@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// UploadFileVersion streams a new content of an existing file into an object of its own and makes it the current version.
+// Older versions stay available through GetFile and RollbackFile.
+func (s *service) UploadFileVersion(stream grpc.ClientStreamingServer[fileservice.UploadFileVersionRequest, fileservice.UploadFileVersionResponse]) error {
+	streamCtx := stream.Context()
+
+	ctx, cancel := s.operationContext(streamCtx)
+	defer cancel()
+
+	firstReq, err := stream.Recv()
+	if err != nil {
+		s.logger.Error("UploadFileVersion: failed to receive first chunk", zap.Error(err))
+		return status.Errorf(codes.InvalidArgument, "failed to receive first chunk: %v", err)
+	}
+
+	id := firstReq.GetFileId()
+	if id == "" {
+		s.logger.Warn("UploadFileVersion: file id is empty")
+		return status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("UploadFileVersion: file not found", zap.String("id", id))
+			return status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("UploadFileVersion: failed to get file info", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorize(ctx, "UploadFileVersion", file, postgres.PermissionWrite)
+	if err != nil {
+		return err
+	}
+
+	if file.Info.GetStatus() != postgres.StatusSuccess {
+		s.logger.Warn("UploadFileVersion: file is not uploaded", zap.String("id", id), zap.String("status", file.Info.GetStatus()))
+		return status.Error(codes.NotFound, "file not found")
+	}
+
+	contentType := firstReq.GetContentType()
+	if contentType == "" {
+		contentType = file.Info.GetContentType()
+	}
+
+	objectKey := tenant.ObjectKey(file.Tenant, uuid.New().String())
+	limits := s.config.Quota.For(file.Tenant)
+
+	remaining, err := s.remainingBytes(ctx, file.Tenant, limits.MaxBytes)
+	if err != nil {
+		s.logger.Error("UploadFileVersion: failed to get usage", zap.String("tenant", file.Tenant), zap.Error(err))
+		return status.Error(codes.Internal, "failed to get usage")
+	}
+
+	reader := newChunkReader(firstReq.GetChunk(), func() ([]byte, error) {
+		req, err := stream.Recv()
+		return req.GetChunk(), err
+	})
+	reader.limit = remaining
+
+	transferCtx, transferCancel := s.transferContext(streamCtx)
+	defer transferCancel()
+
+	err = s.objectStorage.PutObject(transferCtx, objectKey, reader, unknownSize, contentType)
+
+	ctx, finishCancel := s.operationContext(streamCtx)
+	defer finishCancel()
+
+	if err != nil {
+		s.logger.Error("UploadFileVersion: failed to put object", zap.String("id", id), zap.Error(err))
+
+		if errors.Is(reader.err, errQuotaExceeded) {
+			return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+		}
+
+		if reader.err != nil {
+			return status.Errorf(codes.Internal, "failed to receive chunk: %v", reader.err)
+		}
+
+		return status.Errorf(codes.Internal, "failed to put object: %v", err)
+	}
+
+	if !reader.digest.matches(firstReq.GetSha256(), firstReq.GetCrc32C()) {
+		s.logger.Warn("UploadFileVersion: checksum mismatch", zap.String("id", id), zap.String("sha256", reader.digest.SHA256()))
+		s.removeUploadedObject(ctx, objectKey)
+
+		return status.Error(codes.DataLoss, "checksum mismatch")
+	}
+
+	version := &postgres.FileVersion{
+		ObjectKey:   objectKey,
+		Size:        reader.size,
+		ContentType: contentType,
+		Checksum:    reader.digest.SHA256(),
+		CRC32C:      reader.digest.CRC32C(),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if s.config.Dedup {
+		hash, err := s.acquireBlobObject(ctx, objectKey, reader.digest, reader.size)
+		if err != nil {
+			s.logger.Warn("UploadFileVersion: failed to deduplicate, keeping own object", zap.String("id", id), zap.Error(err))
+		} else {
+			version.ObjectKey = minio.BlobKey(hash)
+			version.BlobHash = hash
+		}
+	}
+
+	number, err := s.metaStorage.AddFileVersion(ctx, id, version, limits.MaxBytes)
+	if err != nil {
+		if version.BlobHash != "" {
+			s.releaseBlob(ctx, version.BlobHash)
+		}
+		s.removeUploadedObject(ctx, objectKey)
+
+		switch {
+		case errors.Is(err, postgres.ErrNotFound):
+			s.logger.Warn("UploadFileVersion: file not found", zap.String("id", id))
+			return status.Error(codes.NotFound, "file not found")
+		case errors.Is(err, postgres.ErrQuotaExceeded):
+			s.logger.Warn("UploadFileVersion: storage quota exceeded", zap.String("id", id), zap.String("tenant", file.Tenant))
+			return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+		}
+
+		s.logger.Error("UploadFileVersion: failed to add file version", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to add file version: %s", id)
+	}
+
+	if version.BlobHash != "" {
+		s.removeUploadedObject(ctx, objectKey)
+	}
+
+	s.logger.Info("UploadFileVersion: successfully uploaded file version",
+		zap.String("id", id),
+		zap.Int32("version", number),
+		zap.Int64("size", reader.size),
+	)
+	return stream.SendAndClose(&fileservice.UploadFileVersionResponse{
+		FileId:  id,
+		Version: number,
+	})
+}
+
+func (s *service) ListFileVersions(ctx context.Context, req *fileservice.ListFileVersionsRequest) (*fileservice.ListFileVersionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("ListFileVersions: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("ListFileVersions: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("ListFileVersions: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorize(ctx, "ListFileVersions", file, postgres.PermissionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.metaStorage.ListFileVersions(ctx, id)
+	if err != nil {
+		s.logger.Error("ListFileVersions: failed to list file versions", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list file versions: %s", id)
+	}
+
+	resp := &fileservice.ListFileVersionsResponse{
+		Versions:       make([]*fileservice.FileVersion, 0, len(versions)),
+		CurrentVersion: file.Info.GetVersion(),
+	}
+
+	for _, version := range versions {
+		resp.Versions = append(resp.Versions, &fileservice.FileVersion{
+			Version:     version.Number,
+			Size:        version.Size,
+			ContentType: version.ContentType,
+			Checksum:    version.Checksum,
+			Crc32C:      version.CRC32C,
+			CreatedAt:   timestamppb.New(version.CreatedAt),
+		})
+	}
+
+	s.logger.Info("ListFileVersions: successfully listed file versions", zap.String("id", id), zap.Int("count", len(versions)))
+	return resp, nil
+}
+
+func (s *service) RollbackFile(ctx context.Context, req *fileservice.RollbackFileRequest) (*fileservice.RollbackFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("RollbackFile: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	if req.GetVersion() <= 0 {
+		s.logger.Warn("RollbackFile: invalid version", zap.Int32("version", req.GetVersion()))
+		return nil, status.Error(codes.InvalidArgument, "version must be positive")
+	}
+
+	err := s.authorizeFile(ctx, "RollbackFile", id, postgres.PermissionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.metaStorage.SetCurrentVersion(ctx, id, req.GetVersion())
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("RollbackFile: file version not found", zap.String("id", id), zap.Int32("version", req.GetVersion()))
+			return nil, status.Error(codes.NotFound, "file version not found")
+		}
+
+		s.logger.Error("RollbackFile: failed to set current version", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to rollback file: %s", id)
+	}
+
+	s.logger.Info("RollbackFile: successfully rolled back file", zap.String("id", id), zap.Int32("version", req.GetVersion()))
+	return &fileservice.RollbackFileResponse{}, nil
+}
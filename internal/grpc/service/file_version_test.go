@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/tenant"
+)
+
+func TestUploadFileVersionRoundTrip(t *testing.T) {
+	for _, dedup := range []bool{false, true} {
+		name := "own objects"
+		if dedup {
+			name = "dedup"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			s, objects, meta := newTestService(t, &Config{Dedup: dedup})
+			ctx := context.Background()
+
+			first, second := []byte("first content"), []byte("second, longer content")
+
+			id, err := uploadFile(ctx, s, "report.txt", first)
+			if err != nil {
+				t.Fatalf("UploadFile() error = %v", err)
+			}
+
+			number, err := uploadFileVersion(ctx, s, id, second)
+			if err != nil {
+				t.Fatalf("UploadFileVersion() error = %v", err)
+			}
+			if number != 2 {
+				t.Errorf("UploadFileVersion() version = %d, want 2", number)
+			}
+
+			tests := []struct {
+				version int32
+				want    []byte
+			}{
+				{0, second},
+				{2, second},
+				{1, first},
+			}
+
+			for _, tt := range tests {
+				got, err := readFile(ctx, s, id, tt.version)
+				if err != nil {
+					t.Fatalf("GetFile(version %d) error = %v", tt.version, err)
+				}
+				if string(got) != string(tt.want) {
+					t.Errorf("GetFile(version %d) = %q, want %q", tt.version, got, tt.want)
+				}
+			}
+
+			if !dedup {
+				return
+			}
+
+			file, err := meta.GetFileInfo(ctx, id)
+			if err != nil {
+				t.Fatalf("GetFileInfo() error = %v", err)
+			}
+
+			wantKey := minio.BlobKey(tenant.BlobHash(tenant.Default, newDigestOf(second).SHA256()))
+			if file.ObjectKey != wantKey {
+				t.Errorf("object key = %q, want the blob %q", file.ObjectKey, wantKey)
+			}
+
+			// Only the two blobs are left, the objects the versions were uploaded to are removed.
+			if keys := objects.keys(); len(keys) != 2 {
+				t.Errorf("objects = %v, want the two blobs", keys)
+			}
+		})
+	}
+}
+
+// TestUploadFileVersionSharesBlob uploads a version with the content of another file, both keep reading it after one is deleted.
+func TestUploadFileVersionSharesBlob(t *testing.T) {
+	s, _, _ := newTestService(t, &Config{Dedup: true})
+	ctx := context.Background()
+
+	content := []byte("shared content")
+
+	other, err := uploadFile(ctx, s, "other.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	id, err := uploadFile(ctx, s, "report.txt", []byte("draft"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	_, err = uploadFileVersion(ctx, s, id, content)
+	if err != nil {
+		t.Fatalf("UploadFileVersion() error = %v", err)
+	}
+
+	for _, fileID := range []string{id, other} {
+		got, err := readFile(ctx, s, fileID, 0)
+		if err != nil {
+			t.Fatalf("GetFile(%s) error = %v", fileID, err)
+		}
+		if string(got) != string(content) {
+			t.Errorf("GetFile(%s) = %q, want %q", fileID, got, content)
+		}
+	}
+}
+
+func newDigestOf(content []byte) *digest {
+	d := newDigest()
+	d.Write(content)
+
+	return d
+}
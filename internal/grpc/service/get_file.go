@@ -12,10 +12,13 @@ import (
 	"google.golang.org/grpc/status"
 
 	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
 )
 
 func (s *service) GetFile(req *fileservice.GetFileRequest, stream grpc.ServerStreamingServer[fileservice.GetFileResponse]) error {
-	ctx, cancel := context.WithTimeout(stream.Context(), s.config.Timeout)
+	streamCtx := stream.Context()
+
+	ctx, cancel := s.operationContext(streamCtx)
 	defer cancel()
 
 	id := req.GetFileId()
@@ -24,38 +27,116 @@ func (s *service) GetFile(req *fileservice.GetFileRequest, stream grpc.ServerStr
 		return status.Errorf(codes.InvalidArgument, "file id is required")
 	}
 
-	object, err := s.objectStorage.GetObject(ctx, id)
+	offset, length := req.GetOffset(), req.GetLength()
+	if offset < 0 || length < 0 {
+		s.logger.Warn("GetFile: negative range", zap.Int64("offset", offset), zap.Int64("length", length))
+		return status.Error(codes.InvalidArgument, "offset and length must not be negative")
+	}
+
+	if req.GetVersion() < 0 {
+		s.logger.Warn("GetFile: negative version", zap.Int32("version", req.GetVersion()))
+		return status.Error(codes.InvalidArgument, "version must not be negative")
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("GetFile: file not found")
+			return status.Errorf(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("GetFile: failed to get file info", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorize(ctx, "GetFile", file, postgres.PermissionRead)
+	if err != nil {
+		return err
+	}
+
+	trashed := file.Info.GetStatus() == postgres.StatusTrashed
+	if file.Info.GetStatus() != postgres.StatusSuccess && !(trashed && req.GetIncludeTrashed()) {
+		s.logger.Warn("GetFile: file is not uploaded", zap.String("id", id), zap.String("status", file.Info.GetStatus()))
+		return status.Errorf(codes.NotFound, "file not found")
+	}
+
+	version, err := s.fileVersion(ctx, file, req.GetVersion())
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("GetFile: file version not found", zap.String("id", id), zap.Int32("version", req.GetVersion()))
+			return status.Errorf(codes.NotFound, "file version not found")
+		}
+
+		s.logger.Error("GetFile: failed to get file version", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	totalSize, err := s.objectStorage.StatObject(ctx, version.ObjectKey)
 	if err != nil {
 		if errors.Is(err, minio.ErrNotFound) {
 			s.logger.Warn("GetFile: file not found")
 			return status.Errorf(codes.NotFound, "file not found")
 		}
 
-		s.logger.Error("GetFile: failed to get file", zap.String("id", id), zap.Error(err))
+		s.logger.Error("GetFile: failed to stat file", zap.String("id", id), zap.Error(err))
 		return status.Errorf(codes.Internal, "failed to get file: %s", id)
 	}
 
-	defer func() {
-		err = object.Close()
+	if offset > totalSize || (offset == totalSize && totalSize > 0) {
+		s.logger.Warn("GetFile: offset is out of range",
+			zap.String("id", id),
+			zap.Int64("offset", offset),
+			zap.Int64("total_size", totalSize),
+		)
+		return status.Errorf(codes.OutOfRange, "offset %d is out of range, file size is %d", offset, totalSize)
+	}
+
+	if length == 0 || length > totalSize-offset {
+		length = totalSize - offset
+	}
+
+	firstResp := &fileservice.GetFileResponse{
+		FileName:    file.Info.GetName(),
+		TotalSize:   totalSize,
+		RangeStart:  offset,
+		RangeLength: length,
+		Sha256:      version.Checksum,
+		Crc32C:      version.CRC32C,
+		Version:     version.Number,
+	}
+
+	if length == 0 {
+		err = stream.Send(firstResp)
 		if err != nil {
-			s.logger.Warn("GetFile: failed to close object", zap.String("id", id), zap.Error(err))
+			s.logger.Error("GetFile: failed to send file name", zap.String("id", id), zap.Error(err))
+			return status.Errorf(codes.Internal, "failed to send file name: %s", id)
 		}
-	}()
 
-	fileName, err := s.metaStorage.GetFileName(ctx, id)
+		s.logger.Info("GetFile: successfully get empty file", zap.String("id", id))
+		return nil
+	}
+
+	// The object is read lazily while the content is sent, so it gets the transfer context rather than the operation one.
+	transferCtx, transferCancel := s.transferContext(streamCtx)
+	defer transferCancel()
+
+	object, err := s.objectStorage.GetObject(transferCtx, version.ObjectKey, offset, length)
 	if err != nil {
 		if errors.Is(err, minio.ErrNotFound) {
 			s.logger.Warn("GetFile: file not found")
 			return status.Errorf(codes.NotFound, "file not found")
 		}
 
-		s.logger.Error("GetFile: failed to get file name", zap.String("id", id), zap.Error(err))
-		return status.Errorf(codes.Internal, "failed to get file name: %s", id)
+		s.logger.Error("GetFile: failed to get file", zap.String("id", id), zap.Error(err))
+		return status.Errorf(codes.Internal, "failed to get file: %s", id)
 	}
 
-	firstResp := &fileservice.GetFileResponse{
-		FileName: fileName,
-	}
+	defer func() {
+		err = object.Close()
+		if err != nil {
+			s.logger.Warn("GetFile: failed to close object", zap.String("id", id), zap.Error(err))
+		}
+	}()
 
 	err = stream.Send(firstResp)
 	if err != nil {
@@ -67,6 +148,17 @@ func (s *service) GetFile(req *fileservice.GetFileRequest, stream grpc.ServerStr
 
 	for {
 		n, err := object.Read(buf)
+		if n > 0 {
+			resp := &fileservice.GetFileResponse{
+				Chunk: buf[:n],
+			}
+
+			sendErr := stream.Send(resp)
+			if sendErr != nil {
+				s.logger.Error("GetFile: failed to send response", zap.String("id", id), zap.Error(sendErr))
+				return status.Errorf(codes.Internal, "failed to send response: %s", id)
+			}
+		}
 		if errors.Is(err, io.EOF) {
 			break
 		}
@@ -74,18 +166,30 @@ func (s *service) GetFile(req *fileservice.GetFileRequest, stream grpc.ServerStr
 			s.logger.Error("GetFile: failed to read object", zap.String("id", id), zap.Error(err))
 			return status.Errorf(codes.Internal, "failed to get file: %s", id)
 		}
+	}
 
-		resp := &fileservice.GetFileResponse{
-			Chunk: buf[:n],
-		}
+	s.logger.Info("GetFile: successfully get file",
+		zap.String("id", id),
+		zap.Int64("range_start", offset),
+		zap.Int64("range_length", length),
+	)
+	return nil
+}
 
-		err = stream.Send(resp)
-		if err != nil {
-			s.logger.Error("GetFile: failed to send response", zap.String("id", id), zap.Error(err))
-			return status.Errorf(codes.Internal, "failed to send response: %s", id)
-		}
+// fileVersion returns the requested version of the file, zero stands for the current one which is taken from the file itself.
+func (s *service) fileVersion(ctx context.Context, file *postgres.File, number int32) (*postgres.FileVersion, error) {
+	if number != 0 && number != file.Info.GetVersion() {
+		return s.metaStorage.GetFileVersion(ctx, file.Info.GetId(), number)
 	}
 
-	s.logger.Info("GetFile: successfully get file", zap.String("id", id))
-	return nil
+	return &postgres.FileVersion{
+		Number:      file.Info.GetVersion(),
+		ObjectKey:   file.ObjectKey,
+		BlobHash:    file.BlobHash,
+		Size:        file.Info.GetSize(),
+		ContentType: file.Info.GetContentType(),
+		Checksum:    file.Info.GetChecksum(),
+		CRC32C:      file.Info.GetCrc32C(),
+		CreatedAt:   file.Info.GetUpdatedAt().AsTime(),
+	}, nil
 }
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+)
+
+func (s *service) GetFileInfo(ctx context.Context, req *fileservice.GetFileInfoRequest) (*fileservice.GetFileInfoResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("GetFileInfo: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("GetFileInfo: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("GetFileInfo: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorize(ctx, "GetFileInfo", file, postgres.PermissionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &fileservice.GetFileInfoResponse{File: file.Info}
+
+	if req.GetCheckObject() {
+		_, err = s.objectStorage.StatObject(ctx, file.ObjectKey)
+		switch {
+		case err == nil:
+			resp.ObjectExists = true
+
+		case errors.Is(err, minio.ErrNotFound):
+			s.logger.Warn("GetFileInfo: object is missing", zap.String("id", id))
+
+		default:
+			s.logger.Error("GetFileInfo: failed to stat object", zap.String("id", id), zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to check object: %s", id)
+		}
+	}
+
+	s.logger.Info("GetFileInfo: successfully get file info", zap.String("id", id))
+	return resp, nil
+}
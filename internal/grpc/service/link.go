@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+func (s *service) CreateDownloadLink(ctx context.Context, req *fileservice.CreateDownloadLinkRequest) (*fileservice.CreateDownloadLinkResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("CreateDownloadLink: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	expiresAt, err := s.linkExpiry("CreateDownloadLink", req.GetTtl(), req.GetMaxUses())
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("CreateDownloadLink: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("CreateDownloadLink: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorize(ctx, "CreateDownloadLink", file, postgres.PermissionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Info.GetStatus() != postgres.StatusSuccess {
+		s.logger.Warn("CreateDownloadLink: file is not uploaded", zap.String("id", id), zap.String("status", file.Info.GetStatus()))
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	link := &postgres.Link{
+		ID:          uuid.New().String(),
+		Kind:        postgres.LinkDownload,
+		Tenant:      file.Tenant,
+		FileID:      id,
+		Name:        file.Info.GetName(),
+		ContentType: file.Info.GetContentType(),
+		Owner:       owner(ctx),
+		Attachment:  req.GetAttachment(),
+		MaxUses:     req.GetMaxUses(),
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	err = s.metaStorage.CreateLink(ctx, link)
+	if err != nil {
+		s.logger.Error("CreateDownloadLink: failed to create link", zap.String("id", id), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create link")
+	}
+
+	s.logger.Info("CreateDownloadLink: successfully created link", zap.String("id", id), zap.String("link_id", link.ID))
+	return &fileservice.CreateDownloadLinkResponse{
+		Url:       s.config.LinkSigner.URL(s.config.LinkSigner.Token(link.ID, expiresAt)),
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+func (s *service) CreateUploadLink(ctx context.Context, req *fileservice.CreateUploadLinkRequest) (*fileservice.CreateUploadLinkResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	fileName := req.GetFileName()
+	if fileName == "" {
+		s.logger.Warn("CreateUploadLink: empty fileName")
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+
+	contentType := req.GetContentType()
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	expiresAt, err := s.linkExpiry("CreateUploadLink", req.GetTtl(), req.GetMaxUses())
+	if err != nil {
+		return nil, err
+	}
+
+	link := &postgres.Link{
+		ID:          uuid.New().String(),
+		Kind:        postgres.LinkUpload,
+		Tenant:      tenant.FromContext(ctx),
+		Name:        fileName,
+		ContentType: contentType,
+		Owner:       owner(ctx),
+		MaxUses:     req.GetMaxUses(),
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	err = s.metaStorage.CreateLink(ctx, link)
+	if err != nil {
+		s.logger.Error("CreateUploadLink: failed to create link", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create link")
+	}
+
+	s.logger.Info("CreateUploadLink: successfully created link", zap.String("link_id", link.ID))
+	return &fileservice.CreateUploadLinkResponse{
+		Url:       s.config.LinkSigner.URL(s.config.LinkSigner.Token(link.ID, expiresAt)),
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+// linkExpiry validates the options shared by both kinds of links and returns when the link expires.
+func (s *service) linkExpiry(method string, ttl *durationpb.Duration, maxUses int32) (time.Time, error) {
+	if s.config.LinkSigner == nil {
+		s.logger.Warn(method + ": links are disabled")
+		return time.Time{}, status.Error(codes.FailedPrecondition, "links are disabled")
+	}
+
+	if maxUses < 0 {
+		s.logger.Warn(method+": negative max uses", zap.Int32("max_uses", maxUses))
+		return time.Time{}, status.Error(codes.InvalidArgument, "max uses must not be negative")
+	}
+
+	d := s.config.LinkTTL
+	if ttl != nil {
+		if !ttl.IsValid() || ttl.AsDuration() <= 0 {
+			s.logger.Warn(method + ": invalid ttl")
+			return time.Time{}, status.Error(codes.InvalidArgument, "ttl must be positive")
+		}
+
+		d = ttl.AsDuration()
+	}
+
+	if s.config.MaxLinkTTL > 0 && d > s.config.MaxLinkTTL {
+		s.logger.Warn(method+": ttl is too long", zap.Duration("ttl", d))
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "ttl must not exceed %s", s.config.MaxLinkTTL)
+	}
+
+	return time.Now().UTC().Add(d), nil
+}
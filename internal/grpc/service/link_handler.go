@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"fileservice/internal/links"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// linkHandler redeems the links over plain HTTP: GET downloads a file, PUT uploads the request body as a new file.
+// The token is the only credential, the tenant and the owner are the ones the link was created with.
+type linkHandler struct {
+	s *service
+}
+
+func NewLinkHandler(objectStorage ObjectStorage, metaStorage MetaStorage, config *Config, background *Background, logger *zap.Logger) http.Handler {
+	h := &linkHandler{
+		s: &service{
+			metaStorage:   metaStorage,
+			objectStorage: objectStorage,
+			logger:        logger,
+			config:        config,
+			background:    background,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+links.Path+"{token}", h.download)
+	mux.HandleFunc("PUT "+links.Path+"{token}", h.upload)
+
+	return mux
+}
+
+// useLink checks the token and counts a use of its link. The calls over HTTP skip the tenant interceptor,
+// so the links of a disabled tenant are rejected here.
+func (h *linkHandler) useLink(ctx context.Context, method string, token string, kind string) (*postgres.Link, int, error) {
+	if h.s.config.LinkSigner == nil {
+		return nil, http.StatusNotFound, errors.New("links are disabled")
+	}
+
+	now := time.Now().UTC()
+
+	id, err := h.s.config.LinkSigner.Verify(token, now)
+	if err != nil {
+		h.s.logger.Warn(method + ": invalid token")
+		return nil, http.StatusNotFound, errors.New("link not found")
+	}
+
+	link, err := h.s.metaStorage.UseLink(ctx, id, kind, now)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			h.s.logger.Warn(method+": link is expired or used up", zap.String("link_id", id))
+			return nil, http.StatusGone, errors.New("link is expired or used up")
+		}
+
+		h.s.logger.Error(method+": failed to use link", zap.String("link_id", id), zap.Error(err))
+		return nil, http.StatusInternalServerError, errors.New("failed to use link")
+	}
+
+	t, err := h.s.metaStorage.GetTenant(ctx, link.Tenant)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			h.s.logger.Warn(method+": unknown tenant", zap.String("link_id", id), zap.String("tenant", link.Tenant))
+			return nil, http.StatusForbidden, errors.New("unknown tenant")
+		}
+
+		h.s.logger.Error(method+": failed to get tenant", zap.String("link_id", id), zap.String("tenant", link.Tenant), zap.Error(err))
+		return nil, http.StatusInternalServerError, errors.New("failed to use link")
+	}
+
+	if t.Disabled {
+		h.s.logger.Warn(method+": tenant is disabled", zap.String("link_id", id), zap.String("tenant", link.Tenant))
+		return nil, http.StatusForbidden, errors.New("tenant is disabled")
+	}
+
+	return link, 0, nil
+}
+
+func (h *linkHandler) download(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.s.operationContext(r.Context())
+	defer cancel()
+
+	link, code, err := h.useLink(ctx, "DownloadLink", r.PathValue("token"), postgres.LinkDownload)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	ctx = tenant.NewContext(ctx, link.Tenant)
+
+	file, err := h.s.getFile(ctx, link.FileID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			h.s.logger.Warn("DownloadLink: file not found", zap.String("id", link.FileID))
+			http.Error(w, "file not found", http.StatusNotFound)
+			return
+		}
+
+		h.s.logger.Error("DownloadLink: failed to get file info", zap.String("id", link.FileID), zap.Error(err))
+		http.Error(w, "failed to get file", http.StatusInternalServerError)
+		return
+	}
+
+	if file.Info.GetStatus() != postgres.StatusSuccess {
+		h.s.logger.Warn("DownloadLink: file is not uploaded", zap.String("id", link.FileID), zap.String("status", file.Info.GetStatus()))
+		http.Error(w, "file not found", http.StatusNotFound)
+		return
+	}
+
+	version, err := h.s.fileVersion(ctx, file, 0)
+	if err != nil {
+		h.s.logger.Error("DownloadLink: failed to get file version", zap.String("id", link.FileID), zap.Error(err))
+		http.Error(w, "failed to get file", http.StatusInternalServerError)
+		return
+	}
+
+	transferCtx, transferCancel := h.s.transferContext(tenant.NewContext(r.Context(), link.Tenant))
+	defer transferCancel()
+
+	object, err := h.s.objectStorage.GetObject(transferCtx, version.ObjectKey, 0, 0)
+	if err != nil {
+		h.s.logger.Error("DownloadLink: failed to get file", zap.String("id", link.FileID), zap.Error(err))
+		http.Error(w, "failed to get file", http.StatusInternalServerError)
+		return
+	}
+
+	defer func() {
+		err = object.Close()
+		if err != nil {
+			h.s.logger.Warn("DownloadLink: failed to close object", zap.String("id", link.FileID), zap.Error(err))
+		}
+	}()
+
+	disposition := "inline"
+	if link.Attachment {
+		disposition = "attachment"
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", version.ContentType)
+	header.Set("Content-Length", strconv.FormatInt(version.Size, 10))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": file.Info.GetName()}))
+
+	_, err = io.CopyBuffer(w, object, make([]byte, h.s.config.BufSize))
+	if err != nil {
+		h.s.logger.Error("DownloadLink: failed to send file", zap.String("id", link.FileID), zap.Error(err))
+		return
+	}
+
+	h.s.logger.Info("DownloadLink: successfully sent file", zap.String("id", link.FileID), zap.String("link_id", link.ID))
+}
+
+func (h *linkHandler) upload(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.s.operationContext(r.Context())
+	defer cancel()
+
+	link, code, err := h.useLink(ctx, "UploadLink", r.PathValue("token"), postgres.LinkUpload)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	ctx = tenant.NewContext(ctx, link.Tenant)
+	limits := h.s.config.Quota.For(link.Tenant)
+
+	remaining, err := h.s.remainingBytes(ctx, link.Tenant, limits.MaxBytes)
+	if err != nil {
+		h.s.logger.Error("UploadLink: failed to get usage", zap.String("tenant", link.Tenant), zap.Error(err))
+		http.Error(w, "failed to get usage", http.StatusInternalServerError)
+		return
+	}
+
+	if remaining >= 0 && r.ContentLength > remaining {
+		h.s.logger.Warn("UploadLink: storage quota exceeded", zap.String("tenant", link.Tenant))
+		http.Error(w, "storage quota exceeded", http.StatusInsufficientStorage)
+		return
+	}
+
+	id := uuid.New().String()
+	objectKey := tenant.ObjectKey(link.Tenant, id)
+	now := time.Now().UTC()
+
+	err = h.s.metaStorage.SaveFileInfo(ctx, &postgres.PendingFile{
+		ID:          id,
+		Tenant:      link.Tenant,
+		ObjectKey:   objectKey,
+		Name:        link.Name,
+		ContentType: link.ContentType,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Owner:       link.Owner,
+	}, limits.MaxFiles)
+	if err != nil {
+		if errors.Is(err, postgres.ErrQuotaExceeded) {
+			h.s.logger.Warn("UploadLink: file quota exceeded", zap.String("tenant", link.Tenant))
+			http.Error(w, "file quota exceeded", http.StatusInsufficientStorage)
+			return
+		}
+
+		h.s.logger.Error("UploadLink: failed to save file info", zap.Error(err))
+		http.Error(w, "failed to save file info", http.StatusInternalServerError)
+		return
+	}
+
+	buf := make([]byte, h.s.config.BufSize)
+	reader := newChunkReader(nil, func() ([]byte, error) {
+		n, err := r.Body.Read(buf)
+		if n > 0 {
+			return buf[:n], nil
+		}
+		return nil, err
+	})
+	reader.limit = remaining
+
+	size := r.ContentLength
+	if size < 0 {
+		size = unknownSize
+	}
+
+	requestCtx := tenant.NewContext(r.Context(), link.Tenant)
+
+	transferCtx, transferCancel := h.s.transferContext(requestCtx)
+	defer transferCancel()
+
+	stopRefresh := h.s.keepPending(transferCtx, id)
+	err = h.s.objectStorage.PutObject(transferCtx, objectKey, reader, size, link.ContentType)
+	stopRefresh()
+
+	ctx, finishCancel := h.s.operationContext(requestCtx)
+	defer finishCancel()
+
+	if err != nil {
+		h.s.logger.Error("UploadLink: failed to put object", zap.String("id", id), zap.Error(err))
+
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.WithoutCancel(ctx), h.s.config.Timeout)
+		defer cleanupCancel()
+
+		_, err = h.s.metaStorage.DeleteFileInfo(cleanupCtx, id)
+		if err != nil {
+			h.s.logger.Error("UploadLink: failed to delete file info", zap.String("id", id), zap.Error(err))
+		}
+
+		if errors.Is(reader.err, errQuotaExceeded) {
+			http.Error(w, "storage quota exceeded", http.StatusInsufficientStorage)
+			return
+		}
+
+		http.Error(w, "failed to store file", http.StatusInternalServerError)
+		return
+	}
+
+	if h.s.config.Dedup {
+		err = h.s.storeBlob(ctx, id, objectKey, reader.digest, reader.size)
+		if err != nil {
+			h.s.logger.Warn("UploadLink: failed to deduplicate, keeping own object", zap.String("id", id), zap.Error(err))
+		}
+	}
+
+	err = h.s.metaStorage.SetSuccessStatus(ctx, id, reader.size, reader.digest.SHA256(), reader.digest.CRC32C(), limits.MaxBytes)
+	if err != nil {
+		if errors.Is(err, postgres.ErrQuotaExceeded) {
+			h.s.logger.Warn("UploadLink: storage quota exceeded", zap.String("id", id), zap.String("tenant", link.Tenant))
+			h.s.discardUpload(ctx, "UploadLink", id, objectKey)
+			http.Error(w, "storage quota exceeded", http.StatusInsufficientStorage)
+			return
+		}
+
+		h.s.logger.Error("UploadLink: failed to set success status", zap.String("id", id), zap.Error(err))
+		http.Error(w, "failed to store file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(map[string]string{"file_id": id})
+	if err != nil {
+		h.s.logger.Warn("UploadLink: failed to write response", zap.String("id", id), zap.Error(err))
+	}
+
+	h.s.logger.Info("UploadLink: successfully uploaded file",
+		zap.String("id", id),
+		zap.String("link_id", link.ID),
+		zap.Int64("size", reader.size),
+	)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"fileservice/internal/links"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// newTestLink stores a link of the tenant and returns the path it is redeemed at.
+func newTestLink(t *testing.T, s *service, meta *metaStore, kind string, tenantID string, fileID string) string {
+	t.Helper()
+
+	now := time.Now().UTC()
+	link := &postgres.Link{
+		ID:          uuid.New().String(),
+		Kind:        kind,
+		Tenant:      tenantID,
+		FileID:      fileID,
+		Name:        "report.txt",
+		ContentType: defaultContentType,
+		ExpiresAt:   now.Add(time.Hour),
+		CreatedAt:   now,
+	}
+
+	err := meta.CreateLink(context.Background(), link)
+	if err != nil {
+		t.Fatalf("CreateLink() error = %v", err)
+	}
+
+	return links.Path + s.config.LinkSigner.Token(link.ID, link.ExpiresAt)
+}
+
+func TestLinkHandlerDisabledTenant(t *testing.T) {
+	s, objects, meta := newTestService(t, &Config{LinkSigner: links.NewSigner(&links.Config{Secret: "secret"})})
+	handler := NewLinkHandler(objects, meta, s.config, s.background, s.logger)
+
+	meta.tenants["team-a"] = &postgres.Tenant{ID: "team-a"}
+	ctx := tenant.NewContext(context.Background(), "team-a")
+
+	content := []byte("shared over a link")
+
+	id, err := uploadFile(ctx, s, "report.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	serve := func(method string, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte("uploaded over a link"))))
+
+		return rec
+	}
+
+	download := newTestLink(t, s, meta, postgres.LinkDownload, "team-a", id)
+	upload := newTestLink(t, s, meta, postgres.LinkUpload, "team-a", "")
+
+	rec := serve(http.MethodGet, download)
+	if rec.Code != http.StatusOK || rec.Body.String() != string(content) {
+		t.Fatalf("download = %d %q, want 200 with the content", rec.Code, rec.Body.String())
+	}
+
+	rec = serve(http.MethodPut, upload)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("upload = %d %q, want 201", rec.Code, rec.Body.String())
+	}
+
+	err = meta.DisableTenant(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("DisableTenant() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, download},
+		{http.MethodPut, upload},
+	} {
+		rec = serve(tt.method, tt.path)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s after the tenant is disabled = %d, want 403", tt.method, rec.Code)
+		}
+	}
+}
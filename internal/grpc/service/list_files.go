@@ -2,12 +2,20 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	fileservice "github.com/ladev74/protos/gen/go/file_service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
 )
 
 func (s *service) ListFiles(ctx context.Context, req *fileservice.ListFilesRequest) (*fileservice.ListFilesResponse, error) {
@@ -26,14 +34,46 @@ func (s *service) ListFiles(ctx context.Context, req *fileservice.ListFilesReque
 		return nil, status.Error(codes.InvalidArgument, reason)
 	}
 
-	filesInfo, err := s.metaStorage.ListFilesInfo(ctx, limit, offset)
+	filter, reason := buildListFilter(req)
+	if reason != "" {
+		s.logger.Warn(fmt.Sprintf("ListFiles: %s", reason))
+		return nil, status.Error(codes.InvalidArgument, reason)
+	}
+
+	filter.Tenant = tenant.FromContext(ctx)
+	filter.Accessor = accessor(ctx)
+
+	cursor, err := decodePageToken(req.GetPageToken(), req.GetSortBy(), req.GetSortDirection())
+	if err != nil {
+		s.logger.Warn("ListFiles: invalid page token", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	if cursor != nil && offset > 0 {
+		s.logger.Warn("ListFiles: both offset and page token are set")
+		return nil, status.Error(codes.InvalidArgument, "offset cannot be combined with page token")
+	}
+
+	// One extra file tells whether there is a next page.
+	filter.Limit = limit + 1
+	filter.Offset = offset
+	filter.Cursor = cursor
+
+	filesInfo, err := s.metaStorage.ListFilesInfo(ctx, filter)
 	if err != nil {
 		s.logger.Error("ListFiles: cannot get for files", zap.Error(err))
 		return nil, status.Error(codes.Internal, "cannot get files")
 	}
 
-	s.logger.Info("ListFiles: successfully get files", zap.Int("count", len(filesInfo)))
-	return &fileservice.ListFilesResponse{Files: filesInfo}, nil
+	resp := &fileservice.ListFilesResponse{Files: filesInfo}
+
+	if int64(len(filesInfo)) > limit {
+		resp.Files = filesInfo[:limit]
+		resp.NextPageToken = encodePageToken(resp.Files[limit-1], req.GetSortBy(), req.GetSortDirection())
+	}
+
+	s.logger.Info("ListFiles: successfully get files", zap.Int("count", len(resp.Files)))
+	return resp, nil
 }
 
 func validateLimit(limit int64, cfg *Config) (int64, string) {
@@ -63,3 +103,161 @@ func validateOffset(offset int64, cfg *Config) (int64, string) {
 
 	return offset, ""
 }
+
+// buildListFilter validates the filters and the sort order of the request.
+func buildListFilter(req *fileservice.ListFilesRequest) (*postgres.ListFilter, string) {
+	filter := &postgres.ListFilter{
+		NamePrefix:   req.GetNamePrefix(),
+		NameContains: req.GetNameContains(),
+		ContentType:  req.GetContentType(),
+		MinSize:      req.GetMinSize(),
+		MaxSize:      req.GetMaxSize(),
+		Descending:   req.GetSortDirection() == fileservice.SortDirection_SORT_DIRECTION_DESC,
+		NotExpiredAt: time.Now().UTC(),
+	}
+
+	switch req.GetStatus() {
+	case "":
+		filter.HideStatuses = []string{postgres.StatusDeleting, postgres.StatusTrashed}
+		if !req.GetIncludePending() {
+			filter.HideStatuses = append(filter.HideStatuses, postgres.StatusPending)
+		}
+	case postgres.StatusPending, postgres.StatusSuccess, postgres.StatusDeleting, postgres.StatusTrashed:
+		filter.Status = req.GetStatus()
+	default:
+		return nil, "unknown status"
+	}
+
+	switch req.GetSortBy() {
+	case fileservice.SortField_SORT_FIELD_CREATED_AT:
+		filter.SortBy = postgres.SortByCreatedAt
+	case fileservice.SortField_SORT_FIELD_UPDATED_AT:
+		filter.SortBy = postgres.SortByUpdatedAt
+	case fileservice.SortField_SORT_FIELD_NAME:
+		filter.SortBy = postgres.SortByName
+	case fileservice.SortField_SORT_FIELD_SIZE:
+		filter.SortBy = postgres.SortBySize
+	default:
+		return nil, "unknown sort field"
+	}
+
+	if req.GetSortDirection() != fileservice.SortDirection_SORT_DIRECTION_DESC &&
+		req.GetSortDirection() != fileservice.SortDirection_SORT_DIRECTION_ASC {
+		return nil, "unknown sort direction"
+	}
+
+	if filter.MinSize < 0 || filter.MaxSize < 0 {
+		return nil, "size bounds must not be negative"
+	}
+
+	if filter.MaxSize > 0 && filter.MinSize > filter.MaxSize {
+		return nil, "min_size must not exceed max_size"
+	}
+
+	if req.GetCreatedAfter() != nil {
+		filter.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+
+	if req.GetCreatedBefore() != nil {
+		filter.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore) {
+		return nil, "created_after must be before created_before"
+	}
+
+	return filter, ""
+}
+
+// pageToken is the keyset of the last file of a page. It is serialized into an opaque string,
+// so clients do not depend on its structure. The sort order is kept to reject tokens of another listing.
+type pageToken struct {
+	SortBy    fileservice.SortField     `json:"s"`
+	Direction fileservice.SortDirection `json:"d"`
+	Value     json.RawMessage           `json:"v"`
+	ID        string                    `json:"i"`
+}
+
+func encodePageToken(file *fileservice.FileInfo, sortBy fileservice.SortField, direction fileservice.SortDirection) string {
+	var value any
+
+	switch sortBy {
+	case fileservice.SortField_SORT_FIELD_UPDATED_AT:
+		value = file.GetUpdatedAt().AsTime()
+	case fileservice.SortField_SORT_FIELD_NAME:
+		value = file.GetName()
+	case fileservice.SortField_SORT_FIELD_SIZE:
+		value = file.GetSize()
+	default:
+		value = file.GetCreatedAt().AsTime()
+	}
+
+	rawValue, _ := json.Marshal(value)
+
+	data, _ := json.Marshal(pageToken{
+		SortBy:    sortBy,
+		Direction: direction,
+		Value:     rawValue,
+		ID:        file.GetId(),
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string, sortBy fileservice.SortField, direction fileservice.SortDirection) (*postgres.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decodePageToken: %w", err)
+	}
+
+	var decoded pageToken
+
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, fmt.Errorf("decodePageToken: %w", err)
+	}
+
+	if decoded.SortBy != sortBy || decoded.Direction != direction {
+		return nil, errors.New("decodePageToken: token belongs to another sort order")
+	}
+
+	if len(decoded.Value) == 0 || string(decoded.Value) == "null" {
+		return nil, errors.New("decodePageToken: incomplete token")
+	}
+
+	_, err = uuid.Parse(decoded.ID)
+	if err != nil {
+		return nil, fmt.Errorf("decodePageToken: %w", err)
+	}
+
+	var value any
+
+	switch sortBy {
+	case fileservice.SortField_SORT_FIELD_NAME:
+		var name string
+		err = json.Unmarshal(decoded.Value, &name)
+		value = name
+
+	case fileservice.SortField_SORT_FIELD_SIZE:
+		var size int64
+		err = json.Unmarshal(decoded.Value, &size)
+		value = size
+
+	default:
+		var date time.Time
+		err = json.Unmarshal(decoded.Value, &date)
+		value = date
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decodePageToken: %w", err)
+	}
+
+	return &postgres.Cursor{
+		Value: value,
+		ID:    decoded.ID,
+	}, nil
+}
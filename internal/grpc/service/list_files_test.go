@@ -0,0 +1,111 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	file := &fileservice.FileInfo{
+		Id:        "6f1c2c1e-8a43-4e43-9a8e-2f1d7b9a5c10",
+		Name:      "report.pdf",
+		Size:      4096,
+		CreatedAt: timestamppb.New(createdAt),
+		UpdatedAt: timestamppb.New(updatedAt),
+	}
+
+	tests := []struct {
+		name      string
+		sortBy    fileservice.SortField
+		direction fileservice.SortDirection
+		want      any
+	}{
+		{"created at", fileservice.SortField_SORT_FIELD_CREATED_AT, fileservice.SortDirection_SORT_DIRECTION_DESC, createdAt},
+		{"updated at", fileservice.SortField_SORT_FIELD_UPDATED_AT, fileservice.SortDirection_SORT_DIRECTION_ASC, updatedAt},
+		{"name", fileservice.SortField_SORT_FIELD_NAME, fileservice.SortDirection_SORT_DIRECTION_ASC, "report.pdf"},
+		{"size", fileservice.SortField_SORT_FIELD_SIZE, fileservice.SortDirection_SORT_DIRECTION_DESC, int64(4096)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := encodePageToken(file, tt.sortBy, tt.direction)
+
+			cursor, err := decodePageToken(token, tt.sortBy, tt.direction)
+			if err != nil {
+				t.Fatalf("decodePageToken() error = %v", err)
+			}
+
+			if cursor.ID != file.GetId() {
+				t.Errorf("cursor.ID = %q, want %q", cursor.ID, file.GetId())
+			}
+
+			if date, ok := tt.want.(time.Time); ok {
+				got, ok := cursor.Value.(time.Time)
+				if !ok || !got.Equal(date) {
+					t.Errorf("cursor.Value = %v, want %v", cursor.Value, date)
+				}
+				return
+			}
+
+			if cursor.Value != tt.want {
+				t.Errorf("cursor.Value = %#v, want %#v", cursor.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePageTokenEmpty(t *testing.T) {
+	cursor, err := decodePageToken("", fileservice.SortField_SORT_FIELD_CREATED_AT, fileservice.SortDirection_SORT_DIRECTION_DESC)
+	if err != nil || cursor != nil {
+		t.Fatalf("decodePageToken(\"\") = %v, %v, want nil, nil", cursor, err)
+	}
+}
+
+func TestDecodePageTokenRejects(t *testing.T) {
+	const id = "6f1c2c1e-8a43-4e43-9a8e-2f1d7b9a5c10"
+
+	encode := func(token pageToken) string {
+		data, err := json.Marshal(token)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	byName := fileservice.SortField_SORT_FIELD_NAME
+	asc := fileservice.SortDirection_SORT_DIRECTION_ASC
+
+	valid := encodePageToken(&fileservice.FileInfo{Id: id, Name: "a.txt"}, byName, asc)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "%%%"},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("not json"))},
+		{"truncated", valid[:len(valid)-4]},
+		{"another field", encodePageToken(&fileservice.FileInfo{Id: id, Size: 1}, fileservice.SortField_SORT_FIELD_SIZE, asc)},
+		{"another direction", encodePageToken(&fileservice.FileInfo{Id: id, Name: "a.txt"}, byName, fileservice.SortDirection_SORT_DIRECTION_DESC)},
+		{"missing value", encode(pageToken{SortBy: byName, Direction: asc, ID: id})},
+		{"tampered id", encode(pageToken{SortBy: byName, Direction: asc, Value: json.RawMessage(`"a.txt"`), ID: "1 OR 1=1"})},
+		{"tampered value type", encode(pageToken{SortBy: byName, Direction: asc, Value: json.RawMessage(`42`), ID: id})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodePageToken(tt.token, byName, asc)
+			if err == nil {
+				t.Fatalf("decodePageToken() = %v, want error", cursor)
+			}
+		})
+	}
+}
@@ -8,15 +8,30 @@ import (
 	fileservice "github.com/ladev74/protos/gen/go/file_service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+
+	"fileservice/internal/links"
+	"fileservice/internal/quota"
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
 )
 
 type Config struct {
-	BufSize       int
-	MaxLimit      int64
-	DefaultLimit  int64
-	MaxOffset     int64
-	DefaultOffset int64
-	Timeout       time.Duration
+	BufSize          int
+	MaxLimit         int64
+	DefaultLimit     int64
+	MaxOffset        int64
+	DefaultOffset    int64
+	Timeout          time.Duration
+	TransferTimeout  time.Duration
+	PendingRefresh   time.Duration
+	UploadSessionTTL time.Duration
+	Dedup            bool
+	AdminGroups      []string
+	Quota            *quota.Config
+	// LinkSigner is nil when links are disabled.
+	LinkSigner *links.Signer
+	LinkTTL    time.Duration
+	MaxLinkTTL time.Duration
 }
 
 type service struct {
@@ -25,28 +40,80 @@ type service struct {
 	metaStorage   MetaStorage
 	logger        *zap.Logger
 	config        *Config
+	background    *Background
 }
 
-func Register(grpc *grpc.Server, objectStorage ObjectStorage, metaStorage MetaStorage, config *Config, logger *zap.Logger) {
+func Register(
+	grpc *grpc.Server,
+	objectStorage ObjectStorage,
+	metaStorage MetaStorage,
+	config *Config,
+	background *Background,
+	logger *zap.Logger,
+) {
 	fileservice.RegisterFileServiceServer(grpc,
 		&service{
 			metaStorage:   metaStorage,
 			objectStorage: objectStorage,
 			logger:        logger,
 			config:        config,
+			background:    background,
+		},
+	)
+
+	fileservice.RegisterTenantServiceServer(grpc,
+		&tenantService{
+			metaStorage: metaStorage,
+			logger:      logger,
+			config:      config,
 		},
 	)
 }
 
 type ObjectStorage interface {
-	PutObject(ctx context.Context, fileId string, reader io.Reader, size int64) error
-	GetObject(ctx context.Context, id string) (io.ReadCloser, error)
+	PutObject(ctx context.Context, fileId string, reader io.Reader, size int64, contentType string) error
+	GetObject(ctx context.Context, id string, offset int64, length int64) (io.ReadCloser, error)
+	StatObject(ctx context.Context, id string) (int64, error)
+	RemoveObject(ctx context.Context, id string) error
+	CopyObject(ctx context.Context, srcID string, dstID string) error
+	NewMultipartUpload(ctx context.Context, id string, contentType string) (string, error)
+	PutObjectPart(ctx context.Context, id string, uploadID string, number int, reader io.Reader, size int64) (string, error)
+	CompleteMultipartUpload(ctx context.Context, id string, uploadID string, parts []minio.Part) error
+	AbortMultipartUpload(ctx context.Context, id string, uploadID string) error
 }
 
 type MetaStorage interface {
-	SaveFileInfo(ctx context.Context, id string, fileName string, createdAt time.Time, updatedAt time.Time) error
-	SetSuccessStatus(ctx context.Context, id string) error
-	ListFilesInfo(ctx context.Context, limit int64, offset int64) ([]*fileservice.FileInfo, error)
-	DeleteFileInfo(ctx context.Context, id string) error
-	GetFileName(ctx context.Context, id string) (string, error)
+	SaveFileInfo(ctx context.Context, file *postgres.PendingFile, maxFiles int64) error
+	SetSuccessStatus(ctx context.Context, id string, size int64, checksum string, crc32c string, maxBytes int64) error
+	SetChecksums(ctx context.Context, id string, checksum string, crc32c string) error
+	TouchPendingFile(ctx context.Context, id string) error
+	ListFilesInfo(ctx context.Context, filter *postgres.ListFilter) ([]*fileservice.FileInfo, error)
+	DeleteFileInfo(ctx context.Context, id string) ([]string, error)
+	MarkDeleting(ctx context.Context, id string, from ...string) error
+	TrashFile(ctx context.Context, id string) error
+	RestoreFile(ctx context.Context, id string) error
+	GetFileInfo(ctx context.Context, id string) (*postgres.File, error)
+	AddFileVersion(ctx context.Context, id string, version *postgres.FileVersion, maxBytes int64) (int32, error)
+	SetCurrentVersion(ctx context.Context, id string, version int32) error
+	ListFileVersions(ctx context.Context, id string) ([]*postgres.FileVersion, error)
+	GetFileVersion(ctx context.Context, id string, version int32) (*postgres.FileVersion, error)
+	GrantAccess(ctx context.Context, id string, granteeType string, grantee string, permissions []string) error
+	RevokeAccess(ctx context.Context, id string, granteeType string, grantee string, permissions []string) error
+	ListAccess(ctx context.Context, id string) ([]*postgres.AccessEntry, error)
+	HasPermission(ctx context.Context, id string, accessor *postgres.Accessor, permission string) (bool, error)
+	CreateTenant(ctx context.Context, tenant *postgres.Tenant) error
+	GetTenant(ctx context.Context, id string) (*postgres.Tenant, error)
+	ListTenants(ctx context.Context) ([]*postgres.Tenant, error)
+	DisableTenant(ctx context.Context, id string) error
+	GetUsage(ctx context.Context, tenant string) (*postgres.Usage, error)
+	CreateLink(ctx context.Context, link *postgres.Link) error
+	UseLink(ctx context.Context, id string, kind string, now time.Time) (*postgres.Link, error)
+	AcquireBlob(ctx context.Context, hash string, size int64) (int64, error)
+	ReleaseBlob(ctx context.Context, hash string) (bool, error)
+	AttachBlob(ctx context.Context, id string, uploadedKey string, hash string, objectKey string) error
+	SaveUploadSession(ctx context.Context, session *postgres.UploadSession) error
+	GetUploadSession(ctx context.Context, id string) (*postgres.UploadSession, error)
+	SetUploadSessionStatus(ctx context.Context, id string, status string) error
+	SaveUploadPart(ctx context.Context, sessionID string, part *postgres.UploadPart) error
+	ListUploadParts(ctx context.Context, sessionID string) ([]*postgres.UploadPart, error)
 }
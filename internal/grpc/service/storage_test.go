@@ -0,0 +1,848 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/quota"
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// objectStore keeps the objects in memory.
+type objectStore struct {
+	ObjectStorage
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	// uploads holds the parts of the multipart uploads by upload id.
+	uploads map[string]map[int][]byte
+	// readDelay slows every read of an object down, the reads still stop once their context ends.
+	readDelay time.Duration
+}
+
+func newObjectStore() *objectStore {
+	return &objectStore{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]map[int][]byte),
+	}
+}
+
+func (o *objectStore) PutObject(_ context.Context, id string, reader io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.objects[id] = data
+	return nil
+}
+
+func (o *objectStore) GetObject(ctx context.Context, id string, offset int64, length int64) (io.ReadCloser, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, ok := o.objects[id]
+	if !ok {
+		return nil, minio.ErrNotFound
+	}
+
+	data = data[offset:]
+	if length > 0 {
+		data = data[:length]
+	}
+
+	return io.NopCloser(&slowReader{ctx: ctx, reader: bytes.NewReader(data), delay: o.readDelay}), nil
+}
+
+type slowReader struct {
+	ctx    context.Context
+	reader io.Reader
+	delay  time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.delay > 0 {
+		select {
+		case <-time.After(r.delay):
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+	}
+
+	return r.reader.Read(p)
+}
+
+func (o *objectStore) StatObject(_ context.Context, id string) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, ok := o.objects[id]
+	if !ok {
+		return 0, minio.ErrNotFound
+	}
+
+	return int64(len(data)), nil
+}
+
+func (o *objectStore) RemoveObject(_ context.Context, id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.objects, id)
+	return nil
+}
+
+func (o *objectStore) CopyObject(_ context.Context, srcID string, dstID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, ok := o.objects[srcID]
+	if !ok {
+		return minio.ErrNotFound
+	}
+
+	o.objects[dstID] = bytes.Clone(data)
+	return nil
+}
+
+func (o *objectStore) NewMultipartUpload(_ context.Context, _ string, _ string) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	uploadID := fmt.Sprintf("upload-%d", len(o.uploads)+1)
+	o.uploads[uploadID] = make(map[int][]byte)
+
+	return uploadID, nil
+}
+
+func (o *objectStore) PutObjectPart(_ context.Context, _ string, uploadID string, number int, reader io.Reader, size int64) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, size))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) < size {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	parts, ok := o.uploads[uploadID]
+	if !ok {
+		return "", minio.ErrUploadNotFound
+	}
+
+	parts[number] = data
+	return fmt.Sprintf("etag-%d", number), nil
+}
+
+func (o *objectStore) CompleteMultipartUpload(_ context.Context, id string, uploadID string, parts []minio.Part) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	uploaded, ok := o.uploads[uploadID]
+	if !ok {
+		return minio.ErrUploadNotFound
+	}
+
+	var data []byte
+	for _, part := range parts {
+		data = append(data, uploaded[part.Number]...)
+	}
+
+	o.objects[id] = data
+	delete(o.uploads, uploadID)
+
+	return nil
+}
+
+func (o *objectStore) keys() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	keys := make([]string, 0, len(o.objects))
+	for key := range o.objects {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// metaStore keeps the rows in memory and follows the semantics of the postgres storage for the calls the tests use.
+type metaStore struct {
+	MetaStorage
+
+	mu       sync.Mutex
+	files    map[string]*postgres.File
+	versions map[string][]*postgres.FileVersion
+	blobs    map[string]int64
+	usage    map[string]*postgres.Usage
+	sessions map[string]*postgres.UploadSession
+	parts    map[string]map[int32]*postgres.UploadPart
+	tenants  map[string]*postgres.Tenant
+	links    map[string]*postgres.Link
+	touches  int
+	// beforeSave runs at the start of SaveFileInfo, outside of the lock.
+	beforeSave func(file *postgres.PendingFile)
+}
+
+func newMetaStore() *metaStore {
+	return &metaStore{
+		files:    make(map[string]*postgres.File),
+		versions: make(map[string][]*postgres.FileVersion),
+		blobs:    make(map[string]int64),
+		usage:    make(map[string]*postgres.Usage),
+		sessions: make(map[string]*postgres.UploadSession),
+		parts:    make(map[string]map[int32]*postgres.UploadPart),
+		tenants:  map[string]*postgres.Tenant{tenant.Default: {ID: tenant.Default}},
+		links:    make(map[string]*postgres.Link),
+	}
+}
+
+func (m *metaStore) tenantUsage(tenant string) *postgres.Usage {
+	usage, ok := m.usage[tenant]
+	if !ok {
+		usage = &postgres.Usage{}
+		m.usage[tenant] = usage
+	}
+
+	return usage
+}
+
+func (m *metaStore) addUsageBytes(tenant string, size int64, maxBytes int64) error {
+	usage := m.tenantUsage(tenant)
+	if maxBytes > 0 && usage.Bytes+size > maxBytes {
+		return fmt.Errorf("addUsageBytes: %w: bytes", postgres.ErrQuotaExceeded)
+	}
+
+	usage.Bytes += size
+	return nil
+}
+
+func (m *metaStore) SaveFileInfo(_ context.Context, file *postgres.PendingFile, maxFiles int64) error {
+	if m.beforeSave != nil {
+		m.beforeSave(file)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[file.ID]; ok {
+		return fmt.Errorf("SaveFileInfo: %w: %s", postgres.ErrAlreadyExists, file.ID)
+	}
+
+	usage := m.tenantUsage(file.Tenant)
+	if maxFiles > 0 && usage.Files >= maxFiles {
+		return fmt.Errorf("SaveFileInfo: %w: files", postgres.ErrQuotaExceeded)
+	}
+
+	usage.Files++
+
+	m.files[file.ID] = &postgres.File{
+		Info: &fileservice.FileInfo{
+			Id:          file.ID,
+			Name:        file.Name,
+			ContentType: file.ContentType,
+			Status:      postgres.StatusPending,
+			Owner:       file.Owner,
+			CreatedAt:   timestamppb.New(file.CreatedAt),
+			UpdatedAt:   timestamppb.New(file.UpdatedAt),
+		},
+		ObjectKey: file.ObjectKey,
+		Tenant:    file.Tenant,
+	}
+
+	return nil
+}
+
+func (m *metaStore) SetSuccessStatus(_ context.Context, id string, size int64, checksum string, crc32c string, maxBytes int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok {
+		return fmt.Errorf("SetSuccessStatus: no rows affected")
+	}
+
+	err := m.addUsageBytes(file.Tenant, size, maxBytes)
+	if err != nil {
+		return err
+	}
+
+	file.Info.Status = postgres.StatusSuccess
+	file.Info.Size = size
+	file.Info.Checksum = checksum
+	file.Info.Crc32C = crc32c
+	file.Info.Version = 1
+
+	if len(m.versions[id]) == 0 {
+		m.versions[id] = append(m.versions[id], &postgres.FileVersion{
+			Number:      1,
+			ObjectKey:   file.ObjectKey,
+			BlobHash:    file.BlobHash,
+			Size:        size,
+			ContentType: file.Info.GetContentType(),
+			Checksum:    checksum,
+			CRC32C:      crc32c,
+			CreatedAt:   file.Info.GetUpdatedAt().AsTime(),
+		})
+	}
+
+	return nil
+}
+
+func (m *metaStore) SetChecksums(_ context.Context, id string, checksum string, crc32c string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok {
+		return fmt.Errorf("SetChecksums: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	file.Info.Checksum = checksum
+	file.Info.Crc32C = crc32c
+
+	return nil
+}
+
+func (m *metaStore) TouchPendingFile(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok || file.Info.GetStatus() != postgres.StatusPending {
+		return fmt.Errorf("TouchPendingFile: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	file.Info.UpdatedAt = timestamppb.Now()
+	m.touches++
+
+	return nil
+}
+
+func (m *metaStore) GetFileInfo(_ context.Context, id string) (*postgres.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok {
+		return nil, fmt.Errorf("GetFileInfo: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	copied := *file
+	copied.Info = copyFileInfo(file.Info)
+
+	return &copied, nil
+}
+
+func (m *metaStore) MarkDeleting(_ context.Context, id string, from ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok || (file.Info.GetStatus() != postgres.StatusDeleting && !slices.Contains(from, file.Info.GetStatus())) {
+		return fmt.Errorf("MarkDeleting: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	file.Info.Status = postgres.StatusDeleting
+	return nil
+}
+
+func (m *metaStore) DeleteFileInfo(_ context.Context, id string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok {
+		return nil, fmt.Errorf("DeleteFileInfo: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	var size int64
+	var blobHashes []string
+
+	for _, version := range m.versions[id] {
+		size += version.Size
+		blobHashes = append(blobHashes, version.BlobHash)
+	}
+
+	if len(blobHashes) == 0 {
+		blobHashes = append(blobHashes, file.BlobHash)
+	}
+
+	usage := m.tenantUsage(file.Tenant)
+	usage.Bytes -= size
+	usage.Files--
+
+	delete(m.files, id)
+	delete(m.versions, id)
+
+	var unused []string
+	for _, hash := range blobHashes {
+		if hash != "" && m.releaseBlob(hash) {
+			unused = append(unused, hash)
+		}
+	}
+
+	return unused, nil
+}
+
+func (m *metaStore) ListFileVersions(_ context.Context, id string) ([]*postgres.FileVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var versions []*postgres.FileVersion
+	for _, version := range m.versions[id] {
+		copied := *version
+		versions = append(versions, &copied)
+	}
+
+	return versions, nil
+}
+
+func (m *metaStore) GetFileVersion(_ context.Context, id string, number int32) (*postgres.FileVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, version := range m.versions[id] {
+		if version.Number == number {
+			copied := *version
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("GetFileVersion: %w: %s", postgres.ErrNotFound, id)
+}
+
+func (m *metaStore) AddFileVersion(_ context.Context, id string, version *postgres.FileVersion, maxBytes int64) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok || file.Info.GetStatus() != postgres.StatusSuccess {
+		return 0, fmt.Errorf("AddFileVersion: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	err := m.addUsageBytes(file.Tenant, version.Size, maxBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	stored := *version
+	stored.Number = int32(len(m.versions[id]) + 1)
+	m.versions[id] = append(m.versions[id], &stored)
+
+	m.setCurrentVersion(file, &stored)
+
+	return stored.Number, nil
+}
+
+func (m *metaStore) SetCurrentVersion(_ context.Context, id string, number int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok {
+		return fmt.Errorf("SetCurrentVersion: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	for _, version := range m.versions[id] {
+		if version.Number == number {
+			m.setCurrentVersion(file, version)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("SetCurrentVersion: %w: %s", postgres.ErrNotFound, id)
+}
+
+func (m *metaStore) setCurrentVersion(file *postgres.File, version *postgres.FileVersion) {
+	file.ObjectKey = version.ObjectKey
+	file.BlobHash = version.BlobHash
+	file.Info.Version = version.Number
+	file.Info.Size = version.Size
+	file.Info.ContentType = version.ContentType
+	file.Info.Checksum = version.Checksum
+	file.Info.Crc32C = version.CRC32C
+	file.Info.UpdatedAt = timestamppb.New(version.CreatedAt)
+}
+
+func (m *metaStore) GetUsage(_ context.Context, tenant string) (*postgres.Usage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	usage := *m.tenantUsage(tenant)
+	return &usage, nil
+}
+
+func (m *metaStore) AcquireBlob(_ context.Context, hash string, _ int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blobs[hash]++
+	return m.blobs[hash], nil
+}
+
+func (m *metaStore) ReleaseBlob(_ context.Context, hash string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.releaseBlob(hash), nil
+}
+
+func (m *metaStore) releaseBlob(hash string) bool {
+	m.blobs[hash]--
+	if m.blobs[hash] > 0 {
+		return false
+	}
+
+	delete(m.blobs, hash)
+	return true
+}
+
+func (m *metaStore) AttachBlob(_ context.Context, id string, uploadedKey string, hash string, objectKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[id]
+	if !ok || file.ObjectKey != uploadedKey {
+		return fmt.Errorf("AttachBlob: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	file.ObjectKey, file.BlobHash = objectKey, hash
+
+	for _, version := range m.versions[id] {
+		if version.ObjectKey == uploadedKey {
+			version.ObjectKey, version.BlobHash = objectKey, hash
+		}
+	}
+
+	return nil
+}
+
+func (m *metaStore) SaveUploadSession(_ context.Context, session *postgres.UploadSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	copied := *session
+	m.sessions[session.ID] = &copied
+	m.parts[session.ID] = make(map[int32]*postgres.UploadPart)
+
+	return nil
+}
+
+func (m *metaStore) GetUploadSession(_ context.Context, id string) (*postgres.UploadSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("GetUploadSession: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	copied := *session
+	return &copied, nil
+}
+
+func (m *metaStore) SetUploadSessionStatus(_ context.Context, id string, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return fmt.Errorf("SetUploadSessionStatus: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	session.Status = status
+	return nil
+}
+
+func (m *metaStore) SaveUploadPart(_ context.Context, sessionID string, part *postgres.UploadPart) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	copied := *part
+	m.parts[sessionID][part.Number] = &copied
+
+	return nil
+}
+
+func (m *metaStore) ListUploadParts(_ context.Context, sessionID string) ([]*postgres.UploadPart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	parts := make([]*postgres.UploadPart, 0, len(m.parts[sessionID]))
+	for _, part := range m.parts[sessionID] {
+		copied := *part
+		parts = append(parts, &copied)
+	}
+
+	slices.SortFunc(parts, func(a, b *postgres.UploadPart) int {
+		return int(a.Number - b.Number)
+	})
+
+	return parts, nil
+}
+
+func (m *metaStore) GetTenant(_ context.Context, id string) (*postgres.Tenant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tenants[id]
+	if !ok {
+		return nil, fmt.Errorf("GetTenant: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	copied := *t
+	return &copied, nil
+}
+
+func (m *metaStore) DisableTenant(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tenants[id]
+	if !ok {
+		return fmt.Errorf("DisableTenant: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	t.Disabled = true
+	return nil
+}
+
+func (m *metaStore) CreateLink(_ context.Context, link *postgres.Link) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	copied := *link
+	m.links[link.ID] = &copied
+
+	return nil
+}
+
+func (m *metaStore) UseLink(_ context.Context, id string, kind string, now time.Time) (*postgres.Link, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	link, ok := m.links[id]
+	if !ok || link.Kind != kind || !now.Before(link.ExpiresAt) || (link.MaxUses > 0 && link.Uses >= link.MaxUses) {
+		return nil, fmt.Errorf("UseLink: %w: %s", postgres.ErrNotFound, id)
+	}
+
+	link.Uses++
+
+	copied := *link
+	return &copied, nil
+}
+
+func copyFileInfo(info *fileservice.FileInfo) *fileservice.FileInfo {
+	return proto.Clone(info).(*fileservice.FileInfo)
+}
+
+// serverStream carries the context of a fake stream, the other methods of grpc.ServerStream are not used.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// clientStream feeds the requests to a client streaming call and keeps its response.
+type clientStream[Req any, Resp any] struct {
+	serverStream
+	requests []*Req
+	resp     *Resp
+	// recvDelay imitates a slow client.
+	recvDelay time.Duration
+}
+
+func (s *clientStream[Req, Resp]) Recv() (*Req, error) {
+	time.Sleep(s.recvDelay)
+
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *clientStream[Req, Resp]) SendAndClose(resp *Resp) error {
+	s.resp = resp
+	return nil
+}
+
+// getFileStream collects the responses of GetFile.
+type getFileStream struct {
+	serverStream
+	responses []*fileservice.GetFileResponse
+}
+
+func (s *getFileStream) Send(resp *fileservice.GetFileResponse) error {
+	// The service reuses its buffer between chunks.
+	resp.Chunk = bytes.Clone(resp.Chunk)
+	s.responses = append(s.responses, resp)
+
+	return nil
+}
+
+func newTestService(t *testing.T, config *Config) (*service, *objectStore, *metaStore) {
+	t.Helper()
+
+	config.BufSize = 4
+	config.Timeout = time.Second
+	config.UploadSessionTTL = time.Hour
+	if config.Quota == nil {
+		config.Quota = &quota.Config{}
+	}
+
+	objects, meta := newObjectStore(), newMetaStore()
+
+	return &service{
+		objectStorage: objects,
+		metaStorage:   meta,
+		logger:        zap.NewNop(),
+		config:        config,
+		background:    NewBackground(),
+	}, objects, meta
+}
+
+// splitChunks cuts the content into chunks of the given size, so the tests go through several stream messages.
+func splitChunks(content []byte, size int) [][]byte {
+	var chunks [][]byte
+	for len(content) > size {
+		chunks = append(chunks, content[:size])
+		content = content[size:]
+	}
+
+	return append(chunks, content)
+}
+
+func uploadFile(ctx context.Context, s *service, name string, content []byte) (string, error) {
+	return uploadFileSlowly(ctx, s, name, content, 0)
+}
+
+func uploadFileSlowly(ctx context.Context, s *service, name string, content []byte, recvDelay time.Duration) (string, error) {
+	var requests []*fileservice.UploadFileRequest
+	for i, chunk := range splitChunks(content, 3) {
+		req := &fileservice.UploadFileRequest{Chunk: chunk}
+		if i == 0 {
+			req.FileName = name
+		}
+
+		requests = append(requests, req)
+	}
+
+	stream := &clientStream[fileservice.UploadFileRequest, fileservice.UploadFileResponse]{
+		serverStream: serverStream{ctx: ctx},
+		requests:     requests,
+		recvDelay:    recvDelay,
+	}
+
+	err := s.UploadFile(stream)
+	if err != nil {
+		return "", err
+	}
+
+	return stream.resp.GetFileId(), nil
+}
+
+func uploadFileVersion(ctx context.Context, s *service, id string, content []byte) (int32, error) {
+	var requests []*fileservice.UploadFileVersionRequest
+	for i, chunk := range splitChunks(content, 3) {
+		req := &fileservice.UploadFileVersionRequest{Chunk: chunk}
+		if i == 0 {
+			req.FileId = id
+		}
+
+		requests = append(requests, req)
+	}
+
+	stream := &clientStream[fileservice.UploadFileVersionRequest, fileservice.UploadFileVersionResponse]{
+		serverStream: serverStream{ctx: ctx},
+		requests:     requests,
+	}
+
+	err := s.UploadFileVersion(stream)
+	if err != nil {
+		return 0, err
+	}
+
+	return stream.resp.GetVersion(), nil
+}
+
+func readFile(ctx context.Context, s *service, id string, version int32) ([]byte, error) {
+	stream := &getFileStream{serverStream: serverStream{ctx: ctx}}
+
+	err := s.GetFile(&fileservice.GetFileRequest{FileId: id, Version: version}, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stream.responses) == 0 {
+		return nil, errors.New("readFile: no responses")
+	}
+
+	var content []byte
+	for _, resp := range stream.responses[1:] {
+		content = append(content, resp.GetChunk()...)
+	}
+
+	return content, nil
+}
+
+// uploadParts starts an upload session and uploads the parts in the given order.
+func uploadParts(ctx context.Context, s *service, name string, parts [][]byte) (string, error) {
+	initiated, err := s.InitiateUpload(ctx, &fileservice.InitiateUploadRequest{FileName: name})
+	if err != nil {
+		return "", err
+	}
+
+	for i, part := range parts {
+		var requests []*fileservice.UploadPartRequest
+		for j, chunk := range splitChunks(part, 3) {
+			req := &fileservice.UploadPartRequest{Chunk: chunk}
+			if j == 0 {
+				req.SessionId = initiated.GetSessionId()
+				req.PartNumber = int32(i + 1)
+				req.Size = int64(len(part))
+			}
+
+			requests = append(requests, req)
+		}
+
+		stream := &clientStream[fileservice.UploadPartRequest, fileservice.UploadPartResponse]{
+			serverStream: serverStream{ctx: ctx},
+			requests:     requests,
+		}
+
+		err = s.UploadPart(stream)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return initiated.GetSessionId(), nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/auth"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+type tenantService struct {
+	fileservice.UnimplementedTenantServiceServer
+	metaStorage MetaStorage
+	logger      *zap.Logger
+	config      *Config
+}
+
+// authorizeAdmin allows the admin groups only, unauthenticated calls are not checked.
+func (s *tenantService) authorizeAdmin(ctx context.Context, method string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.InGroup(s.config.AdminGroups) {
+		return nil
+	}
+
+	s.logger.Warn(method+": caller is not an admin", zap.String("subject", principal.Subject))
+	return status.Error(codes.PermissionDenied, "admin group is required")
+}
+
+func (s *tenantService) CreateTenant(ctx context.Context, req *fileservice.CreateTenantRequest) (*fileservice.CreateTenantResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	err := s.authorizeAdmin(ctx, "CreateTenant")
+	if err != nil {
+		return nil, err
+	}
+
+	id := req.GetId()
+	if !tenant.ValidID(id) {
+		s.logger.Warn("CreateTenant: invalid tenant id", zap.String("tenant", id))
+		return nil, status.Error(codes.InvalidArgument, "tenant id must consist of lowercase letters, digits and dashes")
+	}
+
+	name := req.GetName()
+	if name == "" {
+		name = id
+	}
+
+	t := &postgres.Tenant{
+		ID:        id,
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = s.metaStorage.CreateTenant(ctx, t)
+	if err != nil {
+		if errors.Is(err, postgres.ErrAlreadyExists) {
+			s.logger.Warn("CreateTenant: tenant already exists", zap.String("tenant", id))
+			return nil, status.Error(codes.AlreadyExists, "tenant already exists")
+		}
+
+		s.logger.Error("CreateTenant: failed to create tenant", zap.String("tenant", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to create tenant: %s", id)
+	}
+
+	s.logger.Info("CreateTenant: successfully created tenant", zap.String("tenant", id))
+	return &fileservice.CreateTenantResponse{Tenant: tenantToProto(t)}, nil
+}
+
+func (s *tenantService) ListTenants(ctx context.Context, _ *fileservice.ListTenantsRequest) (*fileservice.ListTenantsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	err := s.authorizeAdmin(ctx, "ListTenants")
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := s.metaStorage.ListTenants(ctx)
+	if err != nil {
+		s.logger.Error("ListTenants: failed to list tenants", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list tenants")
+	}
+
+	resp := &fileservice.ListTenantsResponse{Tenants: make([]*fileservice.Tenant, 0, len(tenants))}
+	for _, t := range tenants {
+		resp.Tenants = append(resp.Tenants, tenantToProto(t))
+	}
+
+	s.logger.Info("ListTenants: successfully listed tenants", zap.Int("count", len(tenants)))
+	return resp, nil
+}
+
+func (s *tenantService) DisableTenant(ctx context.Context, req *fileservice.DisableTenantRequest) (*fileservice.DisableTenantResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	err := s.authorizeAdmin(ctx, "DisableTenant")
+	if err != nil {
+		return nil, err
+	}
+
+	id := req.GetId()
+	if id == "" {
+		s.logger.Warn("DisableTenant: tenant id is empty")
+		return nil, status.Error(codes.InvalidArgument, "tenant id is required")
+	}
+
+	if id == tenant.Default {
+		s.logger.Warn("DisableTenant: default tenant cannot be disabled")
+		return nil, status.Error(codes.FailedPrecondition, "default tenant cannot be disabled")
+	}
+
+	err = s.metaStorage.DisableTenant(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("DisableTenant: tenant not found", zap.String("tenant", id))
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+
+		s.logger.Error("DisableTenant: failed to disable tenant", zap.String("tenant", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to disable tenant: %s", id)
+	}
+
+	s.logger.Info("DisableTenant: successfully disabled tenant", zap.String("tenant", id))
+	return &fileservice.DisableTenantResponse{}, nil
+}
+
+func tenantToProto(t *postgres.Tenant) *fileservice.Tenant {
+	return &fileservice.Tenant{
+		Id:        t.ID,
+		Name:      t.Name,
+		Disabled:  t.Disabled,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/sorage/postgres"
+)
+
+// TrashFile only hides the file, its content is removed by the purge worker once the retention period is over.
+func (s *service) TrashFile(ctx context.Context, req *fileservice.TrashFileRequest) (*fileservice.TrashFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("TrashFile: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	err := s.authorizeFile(ctx, "TrashFile", id, postgres.PermissionDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.metaStorage.TrashFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("TrashFile: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("TrashFile: failed to trash file", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to trash file: %s", id)
+	}
+
+	s.logger.Info("TrashFile: successfully trashed file", zap.String("id", id))
+	return &fileservice.TrashFileResponse{}, nil
+}
+
+func (s *service) RestoreFile(ctx context.Context, req *fileservice.RestoreFileRequest) (*fileservice.RestoreFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("RestoreFile: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	err := s.authorizeFile(ctx, "RestoreFile", id, postgres.PermissionDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.metaStorage.RestoreFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("RestoreFile: file not found in trash", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found in trash")
+		}
+
+		s.logger.Error("RestoreFile: failed to restore file", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to restore file: %s", id)
+	}
+
+	s.logger.Info("RestoreFile: successfully restored file", zap.String("id", id))
+	return &fileservice.RestoreFileResponse{}, nil
+}
+
+// ListTrash is a shortcut for ListFiles filtered by the trashed status.
+func (s *service) ListTrash(ctx context.Context, req *fileservice.ListTrashRequest) (*fileservice.ListTrashResponse, error) {
+	resp, err := s.ListFiles(ctx, &fileservice.ListFilesRequest{
+		Limit:     req.GetLimit(),
+		PageToken: req.GetPageToken(),
+		Status:    postgres.StatusTrashed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileservice.ListTrashResponse{
+		Files:         resp.GetFiles(),
+		NextPageToken: resp.GetNextPageToken(),
+	}, nil
+}
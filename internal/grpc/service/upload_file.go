@@ -1,9 +1,9 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"time"
 
@@ -13,10 +13,22 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+const (
+	// unknownSize tells ObjectStorage that the length of the stream is not known in advance.
+	unknownSize = -1
+
+	defaultContentType = "application/octet-stream"
 )
 
 func (s *service) UploadFile(stream grpc.ClientStreamingServer[fileservice.UploadFileRequest, fileservice.UploadFileResponse]) error {
-	ctx, cancel := context.WithTimeout(stream.Context(), s.config.Timeout)
+	streamCtx := stream.Context()
+
+	ctx, cancel := s.operationContext(streamCtx)
 	defer cancel()
 
 	firstReq, err := stream.Recv()
@@ -31,65 +43,222 @@ func (s *service) UploadFile(stream grpc.ClientStreamingServer[fileservice.Uploa
 		return status.Errorf(codes.InvalidArgument, "filename is required")
 	}
 
-	var buf bytes.Buffer
-
-	if len(firstReq.GetChunk()) > 0 {
-		_, err := buf.Write(firstReq.GetChunk())
-		if err != nil {
-			s.logger.Error("UploadFile: failed to write first chunk to buffer", zap.Error(err))
-			return status.Errorf(codes.Internal, "failed to write first chunk: %v", err)
-		}
-	}
-
-	for {
-		req, err := stream.Recv()
-		if errors.Is(err, io.EOF) {
-			break
-		}
-		if err != nil {
-			s.logger.Error("UploadFile: failed to receive chunk", zap.Error(err))
-			return status.Errorf(codes.Internal, "failed to receive chunk: %v", err)
-		}
-
-		_, err = buf.Write(req.GetChunk())
-		if err != nil {
-			s.logger.Error("UploadFile: failed to write chunk to buffer", zap.Error(err))
-			return status.Errorf(codes.Internal, "failed to write chunk: %v", err)
-		}
+	contentType := firstReq.GetContentType()
+	if contentType == "" {
+		contentType = defaultContentType
 	}
 
 	id := uuid.New().String()
 	createdAt := time.Now().UTC()
 	updatedAt := time.Now().UTC()
 
-	err = s.metaStorage.SaveFileInfo(ctx, id, fileName, createdAt, updatedAt)
+	expiresAt, reason := expiryTime(firstReq.GetExpiresAt(), firstReq.GetTtl(), createdAt)
+	if reason != "" {
+		s.logger.Warn(fmt.Sprintf("UploadFile: %s", reason))
+		return status.Error(codes.InvalidArgument, reason)
+	}
+
+	t := tenant.FromContext(ctx)
+	objectKey := tenant.ObjectKey(t, id)
+	limits := s.config.Quota.For(t)
+
+	remaining, err := s.remainingBytes(ctx, t, limits.MaxBytes)
 	if err != nil {
+		s.logger.Error("UploadFile: failed to get usage", zap.String("tenant", t), zap.Error(err))
+		return status.Error(codes.Internal, "failed to get usage")
+	}
+
+	err = s.metaStorage.SaveFileInfo(ctx, &postgres.PendingFile{
+		ID:          id,
+		Tenant:      t,
+		ObjectKey:   objectKey,
+		Name:        fileName,
+		ContentType: contentType,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		ExpiresAt:   expiresAt,
+		Owner:       owner(ctx),
+	}, limits.MaxFiles)
+	if err != nil {
+		if errors.Is(err, postgres.ErrQuotaExceeded) {
+			s.logger.Warn("UploadFile: file quota exceeded", zap.String("tenant", t))
+			return status.Error(codes.ResourceExhausted, "file quota exceeded")
+		}
+
 		s.logger.Error("UploadFile: failed to save file info", zap.Error(err))
 		return status.Errorf(codes.Internal, "failed to save file info: %v", err)
 	}
 
-	err = s.objectStorage.PutObject(ctx, id, &buf, int64(buf.Len()))
+	reader := newChunkReader(firstReq.GetChunk(), func() ([]byte, error) {
+		req, err := stream.Recv()
+		return req.GetChunk(), err
+	})
+	reader.limit = remaining
+
+	transferCtx, transferCancel := s.transferContext(streamCtx)
+	defer transferCancel()
+
+	stopRefresh := s.keepPending(transferCtx, id)
+	err = s.objectStorage.PutObject(transferCtx, objectKey, reader, unknownSize, contentType)
+	stopRefresh()
+
+	ctx, finishCancel := s.operationContext(streamCtx)
+	defer finishCancel()
+
 	if err != nil {
-		s.logger.Error("UploadFile: failed to put object", zap.Error(err))
-		err = s.metaStorage.DeleteFileInfo(ctx, id)
+		s.logger.Error("UploadFile: failed to put object", zap.String("id", id), zap.Error(err))
+
+		// The row is removed with a fresh context: the request context is most likely already canceled.
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.WithoutCancel(ctx), s.config.Timeout)
+		defer cleanupCancel()
+
+		_, err = s.metaStorage.DeleteFileInfo(cleanupCtx, id)
 		if err != nil {
 			s.logger.Error("UploadFile: failed to delete file info", zap.Error(err))
 			return status.Errorf(codes.Internal, "failed to delete file info: %v", err)
 		}
 
+		if errors.Is(reader.err, errQuotaExceeded) {
+			return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+		}
+
+		if reader.err != nil {
+			return status.Errorf(codes.Internal, "failed to receive chunk: %v", reader.err)
+		}
+
 		return status.Errorf(codes.Internal, "failed to put object: %v", err)
 	}
 
-	err = s.metaStorage.SetSuccessStatus(ctx, id)
+	if !reader.digest.matches(firstReq.GetSha256(), firstReq.GetCrc32C()) {
+		s.logger.Warn("UploadFile: checksum mismatch",
+			zap.String("id", id),
+			zap.String("sha256", reader.digest.SHA256()),
+			zap.String("crc32c", reader.digest.CRC32C()),
+		)
+
+		err = s.objectStorage.RemoveObject(ctx, objectKey)
+		if err != nil {
+			s.logger.Error("UploadFile: failed to remove object", zap.String("id", id), zap.Error(err))
+		}
+
+		_, err = s.metaStorage.DeleteFileInfo(ctx, id)
+		if err != nil {
+			s.logger.Error("UploadFile: failed to delete file info", zap.String("id", id), zap.Error(err))
+		}
+
+		return status.Error(codes.DataLoss, "checksum mismatch")
+	}
+
+	if s.config.Dedup {
+		err = s.storeBlob(ctx, id, objectKey, reader.digest, reader.size)
+		if err != nil {
+			s.logger.Warn("UploadFile: failed to deduplicate, keeping own object", zap.String("id", id), zap.Error(err))
+		}
+	}
+
+	err = s.metaStorage.SetSuccessStatus(ctx, id, reader.size, reader.digest.SHA256(), reader.digest.CRC32C(), limits.MaxBytes)
 	if err != nil {
+		if errors.Is(err, postgres.ErrQuotaExceeded) {
+			s.logger.Warn("UploadFile: storage quota exceeded", zap.String("id", id), zap.String("tenant", t))
+			s.discardUpload(ctx, "UploadFile", id, objectKey)
+			return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+		}
+
 		s.logger.Error("UploadFile: failed to set success status", zap.Error(err))
 		return status.Errorf(codes.Internal, "failed to set success status: %v", err)
 	}
 
-	s.logger.Info("UploadFile: successfully uploaded file", zap.String("id", id))
+	s.logger.Info("UploadFile: successfully uploaded file", zap.String("id", id), zap.Int64("size", reader.size))
 	return stream.SendAndClose(
 		&fileservice.UploadFileResponse{
 			FileId: id,
 		},
 	)
 }
+
+// keepPending refreshes the pending file while its content is streamed, the transfer may last longer than the reconciler
+// waits for stale uploads. The returned function stops the refresh and waits for it to finish.
+func (s *service) keepPending(ctx context.Context, id string) func() {
+	if s.config.PendingRefresh <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.config.PendingRefresh)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				touchCtx, touchCancel := s.operationContext(ctx)
+				err := s.metaStorage.TouchPendingFile(touchCtx, id)
+				touchCancel()
+
+				if err != nil && ctx.Err() == nil {
+					s.logger.Warn("keepPending: failed to refresh pending file", zap.String("id", id), zap.Error(err))
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// chunkReader exposes the chunks of an upload stream as an io.Reader,
+// so the object storage pulls data from the client only as fast as it can store it.
+type chunkReader struct {
+	recv  func() ([]byte, error)
+	chunk []byte
+	size  int64
+	// limit caps the size of the stream, -1 means no limit.
+	limit  int64
+	digest *digest
+	err    error
+}
+
+func newChunkReader(firstChunk []byte, recv func() ([]byte, error)) *chunkReader {
+	return &chunkReader{
+		recv:   recv,
+		chunk:  firstChunk,
+		limit:  -1,
+		digest: newDigest(),
+	}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		chunk, err := r.recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+
+		r.chunk = chunk
+	}
+
+	if r.limit >= 0 && r.size+int64(len(r.chunk)) > r.limit {
+		r.err = errQuotaExceeded
+		return 0, errQuotaExceeded
+	}
+
+	n := copy(p, r.chunk)
+	r.digest.Write(p[:n])
+	r.chunk = r.chunk[n:]
+	r.size += int64(n)
+
+	return n, nil
+}
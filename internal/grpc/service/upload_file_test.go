@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestUploadFileRoundTrip(t *testing.T) {
+	s, _, _ := newTestService(t, &Config{})
+	ctx := context.Background()
+
+	content := []byte("uploaded in several chunks")
+
+	id, err := uploadFile(ctx, s, "report.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	got, err := readFile(ctx, s, id, 0)
+	if err != nil {
+		t.Fatalf("GetFile() error = %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("GetFile() = %q, want %q", got, content)
+	}
+}
+
+// TestUploadFileKeepsPending streams slower than the refresh interval, the pending row is refreshed meanwhile.
+func TestUploadFileKeepsPending(t *testing.T) {
+	s, _, meta := newTestService(t, &Config{PendingRefresh: 10 * time.Millisecond})
+	ctx := context.Background()
+
+	_, err := uploadFileSlowly(ctx, s, "report.txt", []byte("a slow upload"), 15*time.Millisecond)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	meta.mu.Lock()
+	touches := meta.touches
+	meta.mu.Unlock()
+
+	if touches == 0 {
+		t.Error("pending file was not refreshed during a slow upload")
+	}
+}
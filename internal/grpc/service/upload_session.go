@@ -0,0 +1,473 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+// maxPartNumber is the largest part number accepted by S3 compatible storages.
+const maxPartNumber = 10000
+
+func (s *service) InitiateUpload(ctx context.Context, req *fileservice.InitiateUploadRequest) (*fileservice.InitiateUploadResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	fileName := req.GetFileName()
+	if fileName == "" {
+		s.logger.Warn("InitiateUpload: empty fileName")
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+
+	contentType := req.GetContentType()
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	fileID := uuid.New().String()
+	t := tenant.FromContext(ctx)
+	objectKey := tenant.ObjectKey(t, fileID)
+
+	uploadID, err := s.objectStorage.NewMultipartUpload(ctx, objectKey, contentType)
+	if err != nil {
+		s.logger.Error("InitiateUpload: failed to initiate multipart upload", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to initiate upload")
+	}
+
+	now := time.Now().UTC()
+	session := &postgres.UploadSession{
+		ID:          uuid.New().String(),
+		FileID:      fileID,
+		UploadID:    uploadID,
+		Name:        fileName,
+		ContentType: contentType,
+		Status:      postgres.SessionStatusActive,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.config.UploadSessionTTL),
+		Owner:       owner(ctx),
+		Tenant:      t,
+	}
+
+	err = s.metaStorage.SaveUploadSession(ctx, session)
+	if err != nil {
+		s.logger.Error("InitiateUpload: failed to save upload session", zap.Error(err))
+
+		err = s.objectStorage.AbortMultipartUpload(ctx, objectKey, uploadID)
+		if err != nil {
+			s.logger.Error("InitiateUpload: failed to abort multipart upload", zap.Error(err))
+		}
+
+		return nil, status.Error(codes.Internal, "failed to initiate upload")
+	}
+
+	s.logger.Info("InitiateUpload: successfully initiated upload", zap.String("session_id", session.ID))
+	return &fileservice.InitiateUploadResponse{
+		SessionId: session.ID,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+	}, nil
+}
+
+func (s *service) UploadPart(stream grpc.ClientStreamingServer[fileservice.UploadPartRequest, fileservice.UploadPartResponse]) error {
+	streamCtx := stream.Context()
+
+	ctx, cancel := s.operationContext(streamCtx)
+	defer cancel()
+
+	firstReq, err := stream.Recv()
+	if err != nil {
+		s.logger.Error("UploadPart: failed to receive first chunk", zap.Error(err))
+		return status.Errorf(codes.InvalidArgument, "failed to receive first chunk: %v", err)
+	}
+
+	partNumber := firstReq.GetPartNumber()
+	if partNumber < 1 || partNumber > maxPartNumber {
+		s.logger.Warn("UploadPart: invalid part number", zap.Int32("part_number", partNumber))
+		return status.Errorf(codes.InvalidArgument, "part number must be between 1 and %d", maxPartNumber)
+	}
+
+	size := firstReq.GetSize()
+	if size <= 0 {
+		s.logger.Warn("UploadPart: invalid part size", zap.Int64("size", size))
+		return status.Error(codes.InvalidArgument, "part size must be positive")
+	}
+
+	session, err := s.activeUploadSession(ctx, firstReq.GetSessionId())
+	if err != nil {
+		return err
+	}
+
+	err = s.checkPartQuota(ctx, session, partNumber, size)
+	if err != nil {
+		return err
+	}
+
+	reader := newChunkReader(firstReq.GetChunk(), func() ([]byte, error) {
+		req, err := stream.Recv()
+		return req.GetChunk(), err
+	})
+
+	transferCtx, transferCancel := s.transferContext(streamCtx)
+	defer transferCancel()
+
+	etag, err := s.objectStorage.PutObjectPart(transferCtx, tenant.ObjectKey(session.Tenant, session.FileID), session.UploadID, int(partNumber), reader, size)
+
+	ctx, finishCancel := s.operationContext(streamCtx)
+	defer finishCancel()
+
+	if err != nil {
+		s.logger.Error("UploadPart: failed to put part",
+			zap.String("session_id", session.ID),
+			zap.Int32("part_number", partNumber),
+			zap.Error(err),
+		)
+
+		switch {
+		case reader.err != nil:
+			return status.Errorf(codes.Internal, "failed to receive chunk: %v", reader.err)
+		case reader.size < size:
+			return status.Error(codes.InvalidArgument, "part is smaller than declared size")
+		case errors.Is(err, minio.ErrUploadNotFound):
+			return status.Error(codes.NotFound, "upload session not found")
+		}
+
+		return status.Error(codes.Internal, "failed to upload part")
+	}
+
+	probe := make([]byte, 1)
+	if n, _ := reader.Read(probe); n > 0 {
+		s.logger.Warn("UploadPart: part is larger than declared size", zap.String("session_id", session.ID))
+		return status.Error(codes.InvalidArgument, "part is larger than declared size")
+	}
+
+	part := &postgres.UploadPart{
+		Number:    partNumber,
+		ETag:      etag,
+		Size:      size,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = s.metaStorage.SaveUploadPart(ctx, session.ID, part)
+	if err != nil {
+		s.logger.Error("UploadPart: failed to save part", zap.String("session_id", session.ID), zap.Error(err))
+		return status.Error(codes.Internal, "failed to save part")
+	}
+
+	s.logger.Info("UploadPart: successfully uploaded part",
+		zap.String("session_id", session.ID),
+		zap.Int32("part_number", partNumber),
+	)
+	return stream.SendAndClose(
+		&fileservice.UploadPartResponse{
+			PartNumber: partNumber,
+			Size:       size,
+		},
+	)
+}
+
+func (s *service) GetUploadStatus(ctx context.Context, req *fileservice.GetUploadStatusRequest) (*fileservice.GetUploadStatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	session, err := s.uploadSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := s.metaStorage.ListUploadParts(ctx, session.ID)
+	if err != nil {
+		s.logger.Error("GetUploadStatus: failed to list parts", zap.String("session_id", session.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get upload status")
+	}
+
+	resp := &fileservice.GetUploadStatusResponse{
+		SessionId: session.ID,
+		FileName:  session.Name,
+		Status:    session.Status,
+		Parts:     make([]*fileservice.UploadedPart, 0, len(parts)),
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+	}
+
+	if session.Status == postgres.SessionStatusCompleted {
+		resp.FileId = session.FileID
+	}
+
+	for _, part := range parts {
+		resp.Parts = append(resp.Parts, &fileservice.UploadedPart{
+			PartNumber: part.Number,
+			Size:       part.Size,
+			UploadedAt: timestamppb.New(part.CreatedAt),
+		})
+	}
+
+	s.logger.Info("GetUploadStatus: successfully get upload status", zap.String("session_id", session.ID))
+	return resp, nil
+}
+
+func (s *service) CompleteUpload(ctx context.Context, req *fileservice.CompleteUploadRequest) (*fileservice.CompleteUploadResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	session, err := s.uploadSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status == postgres.SessionStatusCompleted {
+		s.logger.Info("CompleteUpload: upload is already completed", zap.String("session_id", session.ID))
+		return &fileservice.CompleteUploadResponse{FileId: session.FileID}, nil
+	}
+
+	parts, err := s.metaStorage.ListUploadParts(ctx, session.ID)
+	if err != nil {
+		s.logger.Error("CompleteUpload: failed to list parts", zap.String("session_id", session.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to complete upload")
+	}
+
+	if len(parts) == 0 {
+		s.logger.Warn("CompleteUpload: no parts uploaded", zap.String("session_id", session.ID))
+		return nil, status.Error(codes.FailedPrecondition, "no parts uploaded")
+	}
+
+	var size int64
+	objectParts := make([]minio.Part, 0, len(parts))
+	for _, part := range parts {
+		size += part.Size
+		objectParts = append(objectParts, minio.Part{
+			Number: int(part.Number),
+			ETag:   part.ETag,
+		})
+	}
+
+	objectKey := tenant.ObjectKey(session.Tenant, session.FileID)
+	limits := s.config.Quota.For(session.Tenant)
+
+	// A previous attempt may have failed after the file row was saved. It is resumed instead of inserting the row again.
+	file, err := s.metaStorage.GetFileInfo(ctx, session.FileID)
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		file = nil
+	case err != nil:
+		s.logger.Error("CompleteUpload: failed to get file info", zap.String("session_id", session.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to complete upload")
+	}
+
+	if file != nil && file.Info.GetStatus() != postgres.StatusPending {
+		s.logger.Info("CompleteUpload: file is already completed", zap.String("session_id", session.ID))
+		return s.finishUploadSession(ctx, session)
+	}
+
+	if file == nil {
+		now := time.Now().UTC()
+
+		err = s.metaStorage.SaveFileInfo(ctx, &postgres.PendingFile{
+			ID:          session.FileID,
+			Tenant:      session.Tenant,
+			ObjectKey:   objectKey,
+			Name:        session.Name,
+			ContentType: session.ContentType,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Owner:       session.Owner,
+		}, limits.MaxFiles)
+		if err != nil {
+			switch {
+			case errors.Is(err, postgres.ErrQuotaExceeded):
+				s.logger.Warn("CompleteUpload: file quota exceeded", zap.String("session_id", session.ID), zap.String("tenant", session.Tenant))
+				return nil, status.Error(codes.ResourceExhausted, "file quota exceeded")
+			case errors.Is(err, postgres.ErrAlreadyExists):
+				// Another call completing the same session has saved the row in the meantime, a retry resumes after it.
+				s.logger.Warn("CompleteUpload: upload is being completed concurrently", zap.String("session_id", session.ID))
+				return nil, status.Error(codes.Aborted, "upload is being completed by another call, retry")
+			}
+
+			s.logger.Error("CompleteUpload: failed to save file info", zap.String("session_id", session.ID), zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to complete upload")
+		}
+	}
+
+	err = s.objectStorage.CompleteMultipartUpload(ctx, objectKey, session.UploadID, objectParts)
+	if err != nil && file != nil && errors.Is(err, minio.ErrUploadNotFound) {
+		// The storage forgets the upload once it is assembled, so a resumed call finds the object instead.
+		_, statErr := s.objectStorage.StatObject(ctx, objectKey)
+		if statErr == nil {
+			err = nil
+		}
+	}
+	if err != nil {
+		s.logger.Error("CompleteUpload: failed to complete multipart upload", zap.String("session_id", session.ID), zap.Error(err))
+
+		_, deleteErr := s.metaStorage.DeleteFileInfo(ctx, session.FileID)
+		if deleteErr != nil {
+			s.logger.Error("CompleteUpload: failed to delete file info", zap.Error(deleteErr))
+		}
+
+		switch {
+		case errors.Is(err, minio.ErrPartTooSmall):
+			return nil, status.Error(codes.FailedPrecondition, "all parts except the last one must be at least 5 MiB")
+		case errors.Is(err, minio.ErrUploadNotFound):
+			return nil, status.Error(codes.NotFound, "upload session not found")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to complete upload")
+	}
+
+	// The checksums are filled in by hashObjectChecksums once the call returns.
+	err = s.metaStorage.SetSuccessStatus(ctx, session.FileID, size, "", "", limits.MaxBytes)
+	if err != nil {
+		if errors.Is(err, postgres.ErrQuotaExceeded) {
+			s.logger.Warn("CompleteUpload: storage quota exceeded", zap.String("session_id", session.ID), zap.String("tenant", session.Tenant))
+			s.discardUpload(ctx, "CompleteUpload", session.FileID, objectKey)
+			return nil, status.Error(codes.ResourceExhausted, "storage quota exceeded")
+		}
+
+		s.logger.Error("CompleteUpload: failed to set success status", zap.String("session_id", session.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to complete upload")
+	}
+
+	s.background.Go(ctx, func(ctx context.Context) {
+		s.hashObjectChecksums(ctx, session.FileID, objectKey, size)
+	})
+
+	return s.finishUploadSession(ctx, session)
+}
+
+// finishUploadSession marks the session as completed once its file is stored.
+func (s *service) finishUploadSession(ctx context.Context, session *postgres.UploadSession) (*fileservice.CompleteUploadResponse, error) {
+	err := s.metaStorage.SetUploadSessionStatus(ctx, session.ID, postgres.SessionStatusCompleted)
+	if err != nil {
+		s.logger.Error("CompleteUpload: failed to set session status", zap.String("session_id", session.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to complete upload")
+	}
+
+	s.logger.Info("CompleteUpload: successfully completed upload",
+		zap.String("session_id", session.ID),
+		zap.String("id", session.FileID),
+	)
+	return &fileservice.CompleteUploadResponse{FileId: session.FileID}, nil
+}
+
+// hashObjectChecksums records the checksums of a file assembled from parts and moves it into a blob when deduplication is on.
+// Parts arrive in separate streams, so the whole object has to be read again. It is done in the background
+// not to hold the call up on large files; if the shutdown cancels it, VerifyFile fills the checksums in later.
+func (s *service) hashObjectChecksums(ctx context.Context, id string, objectKey string, size int64) {
+	ctx, cancel := s.transferContext(ctx)
+	defer cancel()
+
+	d, err := s.hashObject(ctx, objectKey)
+	if err != nil {
+		s.logger.Warn("hashObjectChecksums: failed to compute checksums", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	err = s.metaStorage.SetChecksums(ctx, id, d.SHA256(), d.CRC32C())
+	if err != nil {
+		s.logger.Warn("hashObjectChecksums: failed to save checksums", zap.String("id", id), zap.Error(err))
+		return
+	}
+
+	if s.config.Dedup {
+		err = s.storeBlob(ctx, id, objectKey, d, size)
+		if err != nil {
+			s.logger.Warn("hashObjectChecksums: failed to deduplicate, keeping own object", zap.String("id", id), zap.Error(err))
+		}
+	}
+}
+
+// checkPartQuota rejects a part which would take the session over the storage quota of its tenant.
+// Parts uploaded earlier count as well, except a previous upload of the same part which is replaced.
+func (s *service) checkPartQuota(ctx context.Context, session *postgres.UploadSession, partNumber int32, size int64) error {
+	remaining, err := s.remainingBytes(ctx, session.Tenant, s.config.Quota.For(session.Tenant).MaxBytes)
+	if err != nil {
+		s.logger.Error("UploadPart: failed to get usage", zap.String("tenant", session.Tenant), zap.Error(err))
+		return status.Error(codes.Internal, "failed to get usage")
+	}
+	if remaining < 0 {
+		return nil
+	}
+
+	parts, err := s.metaStorage.ListUploadParts(ctx, session.ID)
+	if err != nil {
+		s.logger.Error("UploadPart: failed to list parts", zap.String("session_id", session.ID), zap.Error(err))
+		return status.Error(codes.Internal, "failed to list parts")
+	}
+
+	for _, part := range parts {
+		if part.Number != partNumber {
+			size += part.Size
+		}
+	}
+
+	if size > remaining {
+		s.logger.Warn("UploadPart: storage quota exceeded", zap.String("session_id", session.ID), zap.String("tenant", session.Tenant))
+		return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+	}
+
+	return nil
+}
+
+// uploadSession returns a session that has not expired yet. A session started by an authenticated caller
+// is only available to that caller.
+func (s *service) uploadSession(ctx context.Context, id string) (*postgres.UploadSession, error) {
+	if id == "" {
+		s.logger.Warn("uploadSession: session id is empty")
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+
+	session, err := s.metaStorage.GetUploadSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("uploadSession: session not found", zap.String("session_id", id))
+			return nil, status.Error(codes.NotFound, "upload session not found")
+		}
+
+		s.logger.Error("uploadSession: failed to get session", zap.String("session_id", id), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get upload session")
+	}
+
+	if session.Status == postgres.SessionStatusActive && time.Now().After(session.ExpiresAt) {
+		s.logger.Warn("uploadSession: session expired", zap.String("session_id", id))
+		return nil, status.Error(codes.NotFound, "upload session expired")
+	}
+
+	if session.Tenant != tenant.FromContext(ctx) {
+		s.logger.Warn("uploadSession: session belongs to another tenant", zap.String("session_id", id))
+		return nil, status.Error(codes.NotFound, "upload session not found")
+	}
+
+	if a := accessor(ctx); a != nil && session.Owner != "" && session.Owner != a.Subject {
+		s.logger.Warn("uploadSession: caller is not the owner", zap.String("session_id", id), zap.String("subject", a.Subject))
+		return nil, status.Error(codes.PermissionDenied, "upload session belongs to another principal")
+	}
+
+	return session, nil
+}
+
+// activeUploadSession returns a session that still accepts parts.
+func (s *service) activeUploadSession(ctx context.Context, id string) (*postgres.UploadSession, error) {
+	session, err := s.uploadSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status != postgres.SessionStatusActive {
+		s.logger.Warn("activeUploadSession: session is not active",
+			zap.String("session_id", id),
+			zap.String("status", session.Status),
+		)
+		return nil, status.Error(codes.FailedPrecondition, "upload session is already completed")
+	}
+
+	return session, nil
+}
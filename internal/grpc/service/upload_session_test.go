@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/sorage/postgres"
+)
+
+func TestCompleteUploadRoundTrip(t *testing.T) {
+	for _, dedup := range []bool{false, true} {
+		name := "own object"
+		if dedup {
+			name = "dedup"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			s, _, meta := newTestService(t, &Config{Dedup: dedup})
+			ctx := context.Background()
+
+			parts := [][]byte{[]byte("first part, "), []byte("second part")}
+
+			sessionID, err := uploadParts(ctx, s, "report.txt", parts)
+			if err != nil {
+				t.Fatalf("uploadParts() error = %v", err)
+			}
+
+			resp, err := s.CompleteUpload(ctx, &fileservice.CompleteUploadRequest{SessionId: sessionID})
+			if err != nil {
+				t.Fatalf("CompleteUpload() error = %v", err)
+			}
+
+			// The checksums are computed in the background, waiting for it stands for the shutdown.
+			s.background.Wait(ctx)
+
+			content := []byte("first part, second part")
+
+			got, err := readFile(ctx, s, resp.GetFileId(), 0)
+			if err != nil {
+				t.Fatalf("GetFile() error = %v", err)
+			}
+			if string(got) != string(content) {
+				t.Errorf("GetFile() = %q, want %q", got, content)
+			}
+
+			file, err := meta.GetFileInfo(ctx, resp.GetFileId())
+			if err != nil {
+				t.Fatalf("GetFileInfo() error = %v", err)
+			}
+			if want := newDigestOf(content).SHA256(); file.Info.GetChecksum() != want {
+				t.Errorf("checksum = %q, want %q", file.Info.GetChecksum(), want)
+			}
+			if dedup && file.BlobHash == "" {
+				t.Error("file is not stored as a blob with dedup on")
+			}
+
+			again, err := s.CompleteUpload(ctx, &fileservice.CompleteUploadRequest{SessionId: sessionID})
+			if err != nil || again.GetFileId() != resp.GetFileId() {
+				t.Errorf("repeated CompleteUpload() = %v, %v, want the same file", again.GetFileId(), err)
+			}
+		})
+	}
+}
+
+// TestCompleteUploadConcurrent lets another call save the row between the lookup and the insert of the first one.
+// The loser gets Aborted and a retry returns the completed file.
+func TestCompleteUploadConcurrent(t *testing.T) {
+	s, _, meta := newTestService(t, &Config{})
+	ctx := context.Background()
+
+	sessionID, err := uploadParts(ctx, s, "report.txt", [][]byte{[]byte("content")})
+	if err != nil {
+		t.Fatalf("uploadParts() error = %v", err)
+	}
+
+	var winner *fileservice.CompleteUploadResponse
+	var winnerErr error
+
+	meta.beforeSave = func(*postgres.PendingFile) {
+		meta.beforeSave = nil
+		winner, winnerErr = s.CompleteUpload(ctx, &fileservice.CompleteUploadRequest{SessionId: sessionID})
+	}
+
+	_, err = s.CompleteUpload(ctx, &fileservice.CompleteUploadRequest{SessionId: sessionID})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("losing CompleteUpload() code = %v, want Aborted", status.Code(err))
+	}
+	if winnerErr != nil {
+		t.Fatalf("winning CompleteUpload() error = %v", winnerErr)
+	}
+
+	retried, err := s.CompleteUpload(ctx, &fileservice.CompleteUploadRequest{SessionId: sessionID})
+	if err != nil {
+		t.Fatalf("retried CompleteUpload() error = %v", err)
+	}
+	if retried.GetFileId() != winner.GetFileId() {
+		t.Errorf("retried CompleteUpload() file = %q, want %q", retried.GetFileId(), winner.GetFileId())
+	}
+
+	s.background.Wait(ctx)
+}
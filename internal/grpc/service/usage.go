@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/cleanup"
+	"fileservice/internal/tenant"
+)
+
+// errQuotaExceeded stops an upload stream as soon as it outgrows the remaining quota.
+var errQuotaExceeded = errors.New("quota exceeded")
+
+func (s *service) GetUsage(ctx context.Context, _ *fileservice.GetUsageRequest) (*fileservice.GetUsageResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	t := tenant.FromContext(ctx)
+
+	usage, err := s.metaStorage.GetUsage(ctx, t)
+	if err != nil {
+		s.logger.Error("GetUsage: failed to get usage", zap.String("tenant", t), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get usage")
+	}
+
+	limits := s.config.Quota.For(t)
+
+	s.logger.Info("GetUsage: successfully get usage", zap.String("tenant", t))
+	return &fileservice.GetUsageResponse{
+		Tenant:   t,
+		Bytes:    usage.Bytes,
+		Files:    usage.Files,
+		MaxBytes: limits.MaxBytes,
+		MaxFiles: limits.MaxFiles,
+	}, nil
+}
+
+// remainingBytes returns how many bytes the tenant may still upload, -1 means there is no limit.
+// It only bounds the stream, the final check is done atomically when the upload is recorded.
+func (s *service) remainingBytes(ctx context.Context, t string, maxBytes int64) (int64, error) {
+	if maxBytes <= 0 {
+		return -1, nil
+	}
+
+	usage, err := s.metaStorage.GetUsage(ctx, t)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(maxBytes-usage.Bytes, 0), nil
+}
+
+// discardUpload removes an upload rejected after its content was stored, including a blob it was the only user of.
+func (s *service) discardUpload(ctx context.Context, method string, id string, key string) {
+	err := s.objectStorage.RemoveObject(ctx, key)
+	if err != nil {
+		s.logger.Error(method+": failed to remove object", zap.String("id", id), zap.Error(err))
+	}
+
+	err = cleanup.RemoveFileInfo(ctx, s.objectStorage, s.metaStorage, id, s.logger)
+	if err != nil {
+		s.logger.Error(method+": failed to delete file info", zap.String("id", id), zap.Error(err))
+	}
+}
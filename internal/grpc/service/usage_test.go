@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/quota"
+	"fileservice/internal/tenant"
+)
+
+func TestUploadFileQuota(t *testing.T) {
+	tests := []struct {
+		name    string
+		quota   quota.Config
+		content []byte
+		want    codes.Code
+	}{
+		{"no limits", quota.Config{}, []byte("third file"), codes.OK},
+		{"within limits", quota.Config{MaxBytes: 100, MaxFiles: 3}, []byte("third file"), codes.OK},
+		{"files exceeded", quota.Config{MaxFiles: 2}, []byte("third file"), codes.ResourceExhausted},
+		{"bytes exceeded", quota.Config{MaxBytes: 29}, []byte("third file"), codes.ResourceExhausted},
+		{
+			"tenant override",
+			quota.Config{MaxFiles: 3, Tenants: map[string]quota.Limits{tenant.Default: {MaxFiles: 2}}},
+			[]byte("third file"),
+			codes.ResourceExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, objects, _ := newTestService(t, &Config{Quota: &tt.quota})
+			ctx := context.Background()
+
+			for _, name := range []string{"first.txt", "second.txt"} {
+				_, err := uploadFile(ctx, s, name, []byte("ten bytes!"))
+				if err != nil {
+					t.Fatalf("UploadFile(%s) error = %v", name, err)
+				}
+			}
+
+			_, err := uploadFile(ctx, s, "third.txt", tt.content)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("UploadFile() code = %v, want %v (error = %v)", code, tt.want, err)
+			}
+
+			usage, err := s.GetUsage(ctx, &fileservice.GetUsageRequest{})
+			if err != nil {
+				t.Fatalf("GetUsage() error = %v", err)
+			}
+
+			wantFiles, wantBytes := int64(2), int64(20)
+			if tt.want == codes.OK {
+				wantFiles, wantBytes = 3, 30
+			}
+
+			if usage.GetFiles() != wantFiles || usage.GetBytes() != wantBytes {
+				t.Errorf("GetUsage() = %d files, %d bytes, want %d files, %d bytes",
+					usage.GetFiles(), usage.GetBytes(), wantFiles, wantBytes)
+			}
+
+			// A rejected upload leaves nothing behind.
+			if keys := objects.keys(); int64(len(keys)) != wantFiles {
+				t.Errorf("objects = %v, want %d", keys, wantFiles)
+			}
+		})
+	}
+}
+
+func TestUploadFileVersionQuota(t *testing.T) {
+	s, objects, _ := newTestService(t, &Config{Quota: &quota.Config{MaxBytes: 25}})
+	ctx := context.Background()
+
+	id, err := uploadFile(ctx, s, "report.txt", []byte("ten bytes!"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	_, err = uploadFileVersion(ctx, s, id, []byte("sixteen bytes..."))
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Fatalf("UploadFileVersion() code = %v, want %v (error = %v)", code, codes.ResourceExhausted, err)
+	}
+
+	_, err = uploadFileVersion(ctx, s, id, []byte("fourteen bytes"))
+	if err != nil {
+		t.Fatalf("UploadFileVersion() within the quota error = %v", err)
+	}
+
+	if keys := objects.keys(); len(keys) != 2 {
+		t.Errorf("objects = %v, want the two accepted versions", keys)
+	}
+}
+
+func TestGetUsage(t *testing.T) {
+	s, _, _ := newTestService(t, &Config{Quota: &quota.Config{
+		MaxBytes: 1000,
+		MaxFiles: 10,
+		Tenants:  map[string]quota.Limits{"team-a": {MaxBytes: 50}},
+	}})
+
+	teamCtx := tenant.NewContext(context.Background(), "team-a")
+
+	_, err := uploadFile(teamCtx, s, "report.txt", []byte("ten bytes!"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	tests := []struct {
+		ctx  context.Context
+		want *fileservice.GetUsageResponse
+	}{
+		{context.Background(), &fileservice.GetUsageResponse{Tenant: tenant.Default, MaxBytes: 1000, MaxFiles: 10}},
+		{teamCtx, &fileservice.GetUsageResponse{Tenant: "team-a", Bytes: 10, Files: 1, MaxBytes: 50}},
+	}
+
+	for _, tt := range tests {
+		got, err := s.GetUsage(tt.ctx, &fileservice.GetUsageRequest{})
+		if err != nil {
+			t.Fatalf("GetUsage(%s) error = %v", tt.want.GetTenant(), err)
+		}
+
+		if got.GetTenant() != tt.want.GetTenant() ||
+			got.GetBytes() != tt.want.GetBytes() ||
+			got.GetFiles() != tt.want.GetFiles() ||
+			got.GetMaxBytes() != tt.want.GetMaxBytes() ||
+			got.GetMaxFiles() != tt.want.GetMaxFiles() {
+			t.Errorf("GetUsage(%s) = %v, want %v", tt.want.GetTenant(), got, tt.want)
+		}
+	}
+}
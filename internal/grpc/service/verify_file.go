@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+)
+
+// VerifyFile re-hashes the stored object and compares the result with the checksums saved on upload.
+// Files uploaded before checksums were recorded get them filled in.
+func (s *service) VerifyFile(reqCtx context.Context, req *fileservice.VerifyFileRequest) (*fileservice.VerifyFileResponse, error) {
+	ctx, cancel := s.operationContext(reqCtx)
+	defer cancel()
+
+	id := req.GetFileId()
+	if id == "" {
+		s.logger.Warn("VerifyFile: file id is empty")
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			s.logger.Warn("VerifyFile: file not found", zap.String("id", id))
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		s.logger.Error("VerifyFile: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to get file info: %s", id)
+	}
+
+	err = s.authorize(ctx, "VerifyFile", file, postgres.PermissionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Info.GetStatus() != postgres.StatusSuccess {
+		s.logger.Warn("VerifyFile: file is not uploaded", zap.String("id", id), zap.String("status", file.Info.GetStatus()))
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	// The whole object is read, so it gets the transfer context and the checksums are saved under a fresh operation one.
+	transferCtx, transferCancel := s.transferContext(reqCtx)
+	defer transferCancel()
+
+	d, err := s.hashObject(transferCtx, file.ObjectKey)
+
+	ctx, finishCancel := s.operationContext(reqCtx)
+	defer finishCancel()
+
+	if err != nil {
+		if errors.Is(err, minio.ErrNotFound) {
+			s.logger.Error("VerifyFile: object is missing", zap.String("id", id))
+			return nil, status.Errorf(codes.DataLoss, "object is missing: %s", id)
+		}
+
+		s.logger.Error("VerifyFile: failed to hash object", zap.String("id", id), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to verify file: %s", id)
+	}
+
+	expectedSHA256, expectedCRC32C := file.Info.GetChecksum(), file.Info.GetCrc32C()
+
+	if expectedSHA256 == "" && expectedCRC32C == "" {
+		err = s.metaStorage.SetChecksums(ctx, id, d.SHA256(), d.CRC32C())
+		if err != nil {
+			s.logger.Error("VerifyFile: failed to save checksums", zap.String("id", id), zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to verify file: %s", id)
+		}
+
+		expectedSHA256, expectedCRC32C = d.SHA256(), d.CRC32C()
+	}
+
+	resp := &fileservice.VerifyFileResponse{
+		Valid:          d.matches(expectedSHA256, expectedCRC32C),
+		ExpectedSha256: expectedSHA256,
+		ActualSha256:   d.SHA256(),
+		ExpectedCrc32C: expectedCRC32C,
+		ActualCrc32C:   d.CRC32C(),
+	}
+
+	if !resp.Valid {
+		s.logger.Error("VerifyFile: checksum mismatch",
+			zap.String("id", id),
+			zap.String("expected_sha256", expectedSHA256),
+			zap.String("actual_sha256", resp.ActualSha256),
+		)
+	}
+
+	s.logger.Info("VerifyFile: file verified", zap.String("id", id), zap.Bool("valid", resp.Valid))
+	return resp, nil
+}
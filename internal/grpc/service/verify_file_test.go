@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
+)
+
+func TestVerifyFile(t *testing.T) {
+	s, _, meta := newTestService(t, &Config{})
+	ctx := context.Background()
+
+	content := []byte("content to verify")
+
+	id, err := uploadFile(ctx, s, "report.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	resp, err := s.VerifyFile(ctx, &fileservice.VerifyFileRequest{FileId: id})
+	if err != nil {
+		t.Fatalf("VerifyFile() error = %v", err)
+	}
+	if !resp.GetValid() {
+		t.Errorf("VerifyFile() valid = false, expected %s, actual %s", resp.GetExpectedSha256(), resp.GetActualSha256())
+	}
+
+	err = meta.SetChecksums(ctx, id, newDigestOf([]byte("other content")).SHA256(), "")
+	if err != nil {
+		t.Fatalf("SetChecksums() error = %v", err)
+	}
+
+	resp, err = s.VerifyFile(ctx, &fileservice.VerifyFileRequest{FileId: id})
+	if err != nil {
+		t.Fatalf("VerifyFile() error = %v", err)
+	}
+	if resp.GetValid() {
+		t.Error("VerifyFile() valid = true for a changed checksum")
+	}
+}
+
+// TestVerifyFileSlowObject reads an object for longer than the operation timeout, the missing checksums are still filled in.
+func TestVerifyFileSlowObject(t *testing.T) {
+	s, objects, meta := newTestService(t, &Config{})
+	ctx := context.Background()
+
+	content := []byte("content read slowly")
+
+	id, err := uploadFile(ctx, s, "report.txt", content)
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	err = meta.SetChecksums(ctx, id, "", "")
+	if err != nil {
+		t.Fatalf("SetChecksums() error = %v", err)
+	}
+
+	s.config.Timeout = 50 * time.Millisecond
+	objects.readDelay = 40 * time.Millisecond
+
+	resp, err := s.VerifyFile(ctx, &fileservice.VerifyFileRequest{FileId: id})
+	if err != nil {
+		t.Fatalf("VerifyFile() error = %v", err)
+	}
+
+	want := newDigestOf(content)
+	if !resp.GetValid() || resp.GetExpectedSha256() != want.SHA256() || resp.GetExpectedCrc32C() != want.CRC32C() {
+		t.Errorf("VerifyFile() = %v, want the checksums of the content filled in", resp)
+	}
+
+	file, err := meta.GetFileInfo(ctx, id)
+	if err != nil {
+		t.Fatalf("GetFileInfo() error = %v", err)
+	}
+	if file.Info.GetChecksum() != want.SHA256() {
+		t.Errorf("saved checksum = %q, want %q", file.Info.GetChecksum(), want.SHA256())
+	}
+}
@@ -0,0 +1,114 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Config struct {
+	Interval time.Duration `yaml:"interval" env-default:"10s"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"3s"`
+	// DrainDelay keeps the server running after it reports NOT_SERVING on shutdown,
+	// so load balancers have time to stop sending new calls.
+	DrainDelay time.Duration `yaml:"drain_delay" env-default:"0s"`
+}
+
+// Dependency is reported as a service of its own, so the failing one can be told apart.
+type Dependency struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Prober keeps the statuses of the health service up to date. Every service of the server is serving
+// while all dependencies are reachable.
+type Prober struct {
+	server       *grpchealth.Server
+	dependencies []Dependency
+	services     []string
+	interval     time.Duration
+	timeout      time.Duration
+	logger       *zap.Logger
+	statuses     map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+func NewProber(server *grpchealth.Server, dependencies []Dependency, services []string, config *Config, logger *zap.Logger) *Prober {
+	p := &Prober{
+		server:       server,
+		dependencies: dependencies,
+		// The empty name stands for the server as a whole.
+		services: append([]string{""}, services...),
+		interval: config.Interval,
+		timeout:  config.Timeout,
+		logger:   logger,
+		statuses: make(map[string]healthpb.HealthCheckResponse_ServingStatus),
+	}
+
+	for _, name := range p.services {
+		server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	for _, dependency := range dependencies {
+		server.SetServingStatus(dependency.Name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return p
+}
+
+func (p *Prober) Run(ctx context.Context) {
+	p.probe(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.probe(ctx)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *Prober) probe(ctx context.Context) {
+	overall := healthpb.HealthCheckResponse_SERVING
+
+	for _, dependency := range p.dependencies {
+		checkCtx, cancel := context.WithTimeout(ctx, p.timeout)
+		err := dependency.Check(checkCtx)
+		cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		p.setStatus(dependency.Name, status, err)
+	}
+
+	for _, name := range p.services {
+		p.setStatus(name, overall, nil)
+	}
+}
+
+// setStatus updates the health service and logs the changes of the status only.
+func (p *Prober) setStatus(name string, status healthpb.HealthCheckResponse_ServingStatus, err error) {
+	p.server.SetServingStatus(name, status)
+
+	if previous, ok := p.statuses[name]; ok && previous == status {
+		return
+	}
+	p.statuses[name] = status
+
+	if status == healthpb.HealthCheckResponse_SERVING {
+		p.logger.Info("setStatus: service is serving", zap.String("service", name))
+		return
+	}
+
+	p.logger.Warn("setStatus: service is not serving", zap.String("service", name), zap.Error(err))
+}
@@ -0,0 +1,63 @@
+package httpapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	Enabled           bool          `yaml:"enabled" env-default:"false"`
+	Host              string        `yaml:"host" env-default:"0.0.0.0"`
+	Port              int           `yaml:"port" env-default:"8080"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env-default:"10s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env-default:"10s"`
+}
+
+// App serves the plain HTTP endpoints next to the gRPC server.
+type App struct {
+	server          *http.Server
+	shutdownTimeout time.Duration
+	logger          *zap.Logger
+}
+
+func New(handler http.Handler, log *zap.Logger, config *Config) *App {
+	return &App{
+		server: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", config.Host, config.Port),
+			Handler:           handler,
+			ReadHeaderTimeout: config.ReadHeaderTimeout,
+		},
+		shutdownTimeout: config.ShutdownTimeout,
+		logger:          log,
+	}
+}
+
+func (a *App) Start() error {
+	a.logger.Info("Start: HTTP server is starting", zap.String("addr", a.server.Addr))
+
+	err := a.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.logger.Error("Start: failed to serve", zap.Error(err))
+		return fmt.Errorf("Start: failed to serve: %w", err)
+	}
+
+	return nil
+}
+
+func (a *App) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+
+	err := a.server.Shutdown(ctx)
+	if err != nil {
+		a.logger.Error("Stop: failed to shutdown", zap.Error(err))
+		return fmt.Errorf("Stop: failed to shutdown: %w", err)
+	}
+
+	return nil
+}
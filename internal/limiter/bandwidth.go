@@ -0,0 +1,96 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// BandwidthConfig limits the file content passing through the streams in bytes per second, zero means no limit.
+// The client rate applies to every client separately, the total rate to the whole server.
+type BandwidthConfig struct {
+	ClientRate int64 `yaml:"client_rate" env-default:"0"`
+	TotalRate  int64 `yaml:"total_rate" env-default:"0"`
+}
+
+// newByteLimiter allows a second worth of traffic at once, so the streams are paced smoothly.
+func newByteLimiter(bps int64) *rate.Limiter {
+	if bps <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(rate.Limit(bps), int(bps))
+}
+
+// waitBytes takes n tokens from the bucket. A chunk larger than the burst is taken in several steps.
+// The limiter is swapped as a whole when the limits change, so it is read under the bandwidth lock of the registry
+// together with its burst. The tokens are waited for outside of the lock, so a change does not block on the streams.
+func waitBytes(ctx context.Context, mu *sync.RWMutex, limiter **rate.Limiter, n int) error {
+	for n > 0 {
+		mu.RLock()
+
+		l := *limiter
+		if l.Limit() == rate.Inf {
+			mu.RUnlock()
+			return nil
+		}
+
+		step := min(n, l.Burst())
+		reservation := l.ReserveN(time.Now(), step)
+
+		mu.RUnlock()
+
+		if !reservation.OK() {
+			return fmt.Errorf("waitBytes: cannot reserve %d bytes", step)
+		}
+
+		delay := reservation.Delay()
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				reservation.Cancel()
+				return ctx.Err()
+			}
+		}
+
+		n -= step
+	}
+
+	return nil
+}
+
+// WaitBytes blocks until the client may pass n more bytes, fitting into both its own and the server-wide limit.
+// Waiting clients are served in turn, so a single fast stream cannot starve the others.
+func (r *Registry) WaitBytes(ctx context.Context, c *ClientLimiter, n int) error {
+	err := waitBytes(ctx, &r.bandwidthMu, &c.bandwidth, n)
+	if err != nil {
+		return err
+	}
+
+	return waitBytes(ctx, &r.bandwidthMu, &r.bandwidth, n)
+}
+
+// SetBandwidth changes the limits at runtime, the streams in progress switch to them on the next chunk.
+// The new limiters start with a full burst, the reservations made before the change are still waited for.
+func (r *Registry) SetBandwidth(config BandwidthConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bandwidthMu.Lock()
+	defer r.bandwidthMu.Unlock()
+
+	r.config.Bandwidth = config
+
+	for _, ent := range r.clients {
+		ent.limiter.bandwidth = newByteLimiter(config.ClientRate)
+	}
+
+	r.bandwidth = newByteLimiter(config.TotalRate)
+}
@@ -0,0 +1,207 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func newTestRegistry(t *testing.T, bandwidth BandwidthConfig) *Registry {
+	t.Helper()
+
+	r := NewRegistry(&Config{LoadConcurrent: 1, ReadConcurrent: 1, TTL: time.Minute, Bandwidth: bandwidth})
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func TestNewByteLimiter(t *testing.T) {
+	unlimited := newByteLimiter(0)
+	if unlimited.Limit() != rate.Inf {
+		t.Errorf("newByteLimiter(0).Limit() = %v, want Inf", unlimited.Limit())
+	}
+
+	limited := newByteLimiter(4096)
+	if limited.Limit() != 4096 || limited.Burst() != 4096 {
+		t.Errorf("newByteLimiter(4096) = %v/%d, want 4096/4096", limited.Limit(), limited.Burst())
+	}
+}
+
+func TestWaitBytesUnlimited(t *testing.T) {
+	r := newTestRegistry(t, BandwidthConfig{})
+	c := r.Get("client")
+
+	start := time.Now()
+
+	err := r.WaitBytes(context.Background(), c, 1<<30)
+	if err != nil {
+		t.Fatalf("WaitBytes() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("WaitBytes() took %v without limits", elapsed)
+	}
+}
+
+func TestWaitBytesPacing(t *testing.T) {
+	tests := []struct {
+		name      string
+		bandwidth BandwidthConfig
+		chunks    []int
+		wantMin   time.Duration
+	}{
+		// The burst of 10000 bytes passes at once, the remaining 5000 take half a second.
+		{"client rate", BandwidthConfig{ClientRate: 10000}, []int{10000, 5000}, 400 * time.Millisecond},
+		{"total rate", BandwidthConfig{TotalRate: 10000}, []int{10000, 5000}, 400 * time.Millisecond},
+		// A chunk larger than the burst is taken in steps instead of failing.
+		{"chunk larger than the burst", BandwidthConfig{ClientRate: 10000}, []int{15000}, 400 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRegistry(t, tt.bandwidth)
+			c := r.Get("client")
+
+			start := time.Now()
+
+			for _, n := range tt.chunks {
+				err := r.WaitBytes(context.Background(), c, n)
+				if err != nil {
+					t.Fatalf("WaitBytes(%d) error = %v", n, err)
+				}
+			}
+
+			elapsed := time.Since(start)
+			if elapsed < tt.wantMin || elapsed > tt.wantMin+time.Second {
+				t.Errorf("WaitBytes() took %v, want about %v", elapsed, tt.wantMin)
+			}
+		})
+	}
+}
+
+func TestWaitBytesSharesTotalRate(t *testing.T) {
+	r := newTestRegistry(t, BandwidthConfig{TotalRate: 10000})
+
+	// Two clients spend the total burst together, so the second one has to wait for the first.
+	err := r.WaitBytes(context.Background(), r.Get("a"), 10000)
+	if err != nil {
+		t.Fatalf("WaitBytes() error = %v", err)
+	}
+
+	start := time.Now()
+
+	err = r.WaitBytes(context.Background(), r.Get("b"), 5000)
+	if err != nil {
+		t.Fatalf("WaitBytes() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("second client waited %v, want about 500ms", elapsed)
+	}
+}
+
+func TestWaitBytesCanceled(t *testing.T) {
+	r := newTestRegistry(t, BandwidthConfig{ClientRate: 100})
+	c := r.Get("client")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	err := r.WaitBytes(ctx, c, 1000)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitBytes() error = %v, want DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("WaitBytes() returned after %v, want right after the deadline", elapsed)
+	}
+
+	// The canceled reservation gives its tokens back.
+	if tokens := c.bandwidth.Tokens(); tokens < 0 {
+		t.Errorf("tokens after cancel = %v, want the reservation returned", tokens)
+	}
+}
+
+func TestSetBandwidth(t *testing.T) {
+	r := newTestRegistry(t, BandwidthConfig{ClientRate: 100, TotalRate: 1000})
+	existing := r.Get("existing")
+
+	r.SetBandwidth(BandwidthConfig{ClientRate: 5000})
+
+	if existing.bandwidth.Limit() != 5000 || existing.bandwidth.Burst() != 5000 {
+		t.Errorf("existing client = %v/%d, want 5000/5000", existing.bandwidth.Limit(), existing.bandwidth.Burst())
+	}
+
+	if r.bandwidth.Limit() != rate.Inf {
+		t.Errorf("total limit = %v, want Inf", r.bandwidth.Limit())
+	}
+
+	created := r.Get("created")
+	if created.bandwidth.Limit() != 5000 {
+		t.Errorf("new client limit = %v, want 5000", created.bandwidth.Limit())
+	}
+
+	r.SetBandwidth(BandwidthConfig{})
+
+	start := time.Now()
+
+	err := r.WaitBytes(context.Background(), existing, 1<<20)
+	if err != nil {
+		t.Fatalf("WaitBytes() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("WaitBytes() took %v after the limits were lifted", elapsed)
+	}
+}
+
+// TestSetBandwidthConcurrent changes the limits while the streams wait, no wait may fail on a burst
+// that does not match the rate.
+func TestSetBandwidthConcurrent(t *testing.T) {
+	r := newTestRegistry(t, BandwidthConfig{ClientRate: 1 << 20, TotalRate: 1 << 20})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+
+	for i := 0; i < 4; i++ {
+		c := r.Get(string(rune('a' + i)))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				err := r.WaitBytes(ctx, c, 64<<10)
+				if err != nil && ctx.Err() == nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	rates := []int64{1 << 20, 1 << 10, 0, 1 << 16}
+	for i := 0; ctx.Err() == nil; i++ {
+		rate := rates[i%len(rates)]
+		r.SetBandwidth(BandwidthConfig{ClientRate: rate, TotalRate: rate})
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Lift the limits, so the streams blocked on a low rate return.
+	r.SetBandwidth(BandwidthConfig{})
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("WaitBytes() error = %v", err)
+	}
+}
@@ -1,30 +1,83 @@
 package limiter
 
 import (
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 type Config struct {
 	LoadConcurrent int
 	ReadConcurrent int
 	TTL            time.Duration
+	// Rates limit the requests per second of a client, zero means no limit.
+	// Burst is how many requests may arrive at once, it defaults to the rate rounded up.
+	LoadRate  float64
+	LoadBurst int
+	ReadRate  float64
+	ReadBurst int
+	Bandwidth BandwidthConfig
 }
 
 type ClientLimiter struct {
 	uploadSem chan struct{}
 	listSem   chan struct{}
 
+	uploadRate *rate.Limiter
+	listRate   *rate.Limiter
+	bandwidth  *rate.Limiter
+
 	uploadCount int64
 	listCount   int64
 }
 
 func newClientLimiter(config *Config) *ClientLimiter {
 	return &ClientLimiter{
-		uploadSem: make(chan struct{}, config.LoadConcurrent),
-		listSem:   make(chan struct{}, config.ReadConcurrent),
+		uploadSem:  make(chan struct{}, config.LoadConcurrent),
+		listSem:    make(chan struct{}, config.ReadConcurrent),
+		uploadRate: newRateLimiter(config.LoadRate, config.LoadBurst),
+		listRate:   newRateLimiter(config.ReadRate, config.ReadBurst),
+		bandwidth:  newByteLimiter(config.Bandwidth.ClientRate),
+	}
+}
+
+func newRateLimiter(rps float64, burst int) *rate.Limiter {
+	if rps <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+
+	return rate.NewLimiter(rate.Limit(rps), burst)
+}
+
+// allow takes a token from the bucket, otherwise it reports how long the client has to wait for one.
+func allow(limiter *rate.Limiter) (bool, time.Duration) {
+	reservation := limiter.Reserve()
+	if !reservation.OK() {
+		return false, 0
+	}
+
+	delay := reservation.Delay()
+	if delay > 0 {
+		reservation.Cancel()
+		return false, delay
 	}
+
+	return true, 0
+}
+
+func (c *ClientLimiter) AllowUpload() (bool, time.Duration) {
+	return allow(c.uploadRate)
+}
+
+func (c *ClientLimiter) AllowList() (bool, time.Duration) {
+	return allow(c.listRate)
 }
 
 func (c *ClientLimiter) AcquireUpload() bool {
@@ -71,26 +124,24 @@ type clientEntry struct {
 }
 
 type Registry struct {
-	mu      sync.Mutex
-	clients map[string]*clientEntry
-	config  *Config
-	stop    chan struct{}
+	mu        sync.Mutex
+	clients   map[string]*clientEntry
+	config    *Config
+	stop      chan struct{}
+	bandwidth *rate.Limiter
+	// bandwidthMu guards the byte limiters of the registry and its clients, which are replaced when the limits change.
+	bandwidthMu sync.RWMutex
 
 	OnNewClient func(clientID string)
 	OnPurge     func(clientID string)
 }
 
-func NewRegistry(loadConcurrent int, readConcurrent int, ttl time.Duration) *Registry {
-	config := &Config{
-		LoadConcurrent: loadConcurrent,
-		ReadConcurrent: readConcurrent,
-		TTL:            ttl,
-	}
-
+func NewRegistry(config *Config) *Registry {
 	r := &Registry{
-		clients: make(map[string]*clientEntry),
-		config:  config,
-		stop:    make(chan struct{}),
+		clients:   make(map[string]*clientEntry),
+		config:    config,
+		bandwidth: newByteLimiter(config.Bandwidth.TotalRate),
+		stop:      make(chan struct{}),
 	}
 
 	go r.janitor()
@@ -0,0 +1,94 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		rps       float64
+		burst     int
+		wantLimit rate.Limit
+		wantBurst int
+	}{
+		{"zero rate is unlimited", 0, 5, rate.Inf, 0},
+		{"negative rate is unlimited", -1, 0, rate.Inf, 0},
+		{"burst defaults to the rate", 4, 0, 4, 4},
+		{"default burst is rounded up", 2.5, 0, 2.5, 3},
+		{"fractional rate allows one request", 0.2, 0, 0.2, 1},
+		{"explicit burst", 2, 10, 2, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.rps, tt.burst)
+
+			if l.Limit() != tt.wantLimit {
+				t.Errorf("Limit() = %v, want %v", l.Limit(), tt.wantLimit)
+			}
+
+			if tt.wantLimit != rate.Inf && l.Burst() != tt.wantBurst {
+				t.Errorf("Burst() = %d, want %d", l.Burst(), tt.wantBurst)
+			}
+		})
+	}
+}
+
+func TestAllowUnlimited(t *testing.T) {
+	l := newRateLimiter(0, 0)
+
+	for i := 0; i < 1000; i++ {
+		ok, delay := allow(l)
+		if !ok || delay != 0 {
+			t.Fatalf("allow() = %v, %v on request %d, want true, 0", ok, delay, i)
+		}
+	}
+}
+
+func TestAllowBurstThenReject(t *testing.T) {
+	l := newRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		ok, _ := allow(l)
+		if !ok {
+			t.Fatalf("allow() = false on request %d within the burst", i)
+		}
+	}
+
+	ok, delay := allow(l)
+	if ok {
+		t.Fatal("allow() = true after the burst is spent")
+	}
+
+	if delay <= 0 || delay > time.Second {
+		t.Errorf("delay = %v, want within (0, 1s] at one request per second", delay)
+	}
+
+	// A rejected request does not keep its reservation, so it does not push the next token further away.
+	_, again := allow(l)
+	if again > delay {
+		t.Errorf("delay after a rejection = %v, want at most %v", again, delay)
+	}
+}
+
+func TestClientLimiterKinds(t *testing.T) {
+	c := newClientLimiter(&Config{LoadConcurrent: 1, ReadConcurrent: 1, LoadRate: 1, LoadBurst: 1})
+
+	if ok, _ := c.AllowUpload(); !ok {
+		t.Fatal("AllowUpload() = false on the first request")
+	}
+
+	if ok, _ := c.AllowUpload(); ok {
+		t.Fatal("AllowUpload() = true after the burst is spent")
+	}
+
+	for i := 0; i < 10; i++ {
+		if ok, _ := c.AllowList(); !ok {
+			t.Fatalf("AllowList() = false on request %d, want the read rate to be unlimited", i)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package links
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Path is where the HTTP endpoint redeems the tokens, the token follows it.
+const Path = "/links/"
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type Config struct {
+	// Secret signs the tokens, links are disabled while it is empty.
+	Secret string `yaml:"secret" env:"LINKS_SECRET"`
+	// BaseURL is the address the HTTP endpoint is reachable at from the outside.
+	BaseURL    string        `yaml:"base_url" env-default:"http://localhost:8080"`
+	DefaultTTL time.Duration `yaml:"default_ttl" env-default:"15m"`
+	MaxTTL     time.Duration `yaml:"max_ttl" env-default:"24h"`
+}
+
+// Signer issues the tokens of links and checks them. A token carries the id of the link and its expiry
+// signed with HMAC-SHA256, so forged and expired tokens are rejected before the database is asked.
+type Signer struct {
+	secret  []byte
+	baseURL string
+}
+
+func NewSigner(config *Config) *Signer {
+	if config.Secret == "" {
+		return nil
+	}
+
+	return &Signer{
+		secret:  []byte(config.Secret),
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+	}
+}
+
+func (s *Signer) Token(id string, expiresAt time.Time) string {
+	payload := id + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.sign(payload)
+}
+
+func (s *Signer) URL(token string) string {
+	return s.baseURL + Path + token
+}
+
+// Verify returns the id of the link the token was issued for.
+func (s *Signer) Verify(token string, now time.Time) (string, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return "", ErrInvalidToken
+	}
+
+	payload, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return "", ErrInvalidToken
+	}
+
+	id, expiry, ok := strings.Cut(payload, ".")
+	if !ok || id == "" {
+		return "", ErrInvalidToken
+	}
+
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !time.Unix(unix, 0).After(now) {
+		return "", ErrInvalidToken
+	}
+
+	return id, nil
+}
+
+func (s *Signer) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
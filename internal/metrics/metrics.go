@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const namespace = "fileservice"
+
+type Config struct {
+	Enabled bool   `yaml:"enabled" env-default:"false"`
+	Host    string `yaml:"host" env-default:"0.0.0.0"`
+	Port    int    `yaml:"port" env-default:"9090"`
+	Path    string `yaml:"path" env-default:"/metrics"`
+}
+
+// Metrics collects the metrics of the service and serves them to Prometheus on a port of their own.
+// The collectors are always registered, the port is only opened when the metrics are enabled.
+type Metrics struct {
+	registry *prometheus.Registry
+	server   *http.Server
+	enabled  bool
+	logger   *zap.Logger
+
+	requests        *prometheus.CounterVec
+	duration        *prometheus.HistogramVec
+	bytes           *prometheus.CounterVec
+	retries         *prometheus.CounterVec
+	limiterAcquired *prometheus.CounterVec
+	limiterRejected *prometheus.CounterVec
+	limiterActive   *prometheus.GaugeVec
+	rateLimited     *prometheus.CounterVec
+}
+
+func New(config *Config, logger *zap.Logger) *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		enabled:  config.Enabled,
+		logger:   logger,
+
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Handled gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Duration of gRPC requests by method.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"method"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transferred_bytes_total",
+			Help:      "File content received from and sent to the clients.",
+		}, []string{"method", "direction"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "storage_retries_total",
+			Help:      "Retried calls to the storages.",
+		}, []string{"storage"}),
+		limiterAcquired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "limiter_acquired_total",
+			Help:      "Requests admitted by the concurrency limiter.",
+		}, []string{"kind"}),
+		limiterRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "limiter_rejected_total",
+			Help:      "Requests rejected by the concurrency limiter.",
+		}, []string{"kind"}),
+		limiterActive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "limiter_active",
+			Help:      "Requests currently holding a slot of the concurrency limiter.",
+		}, []string{"kind"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limited_total",
+			Help:      "Requests rejected by the per-client rate limit.",
+		}, []string{"kind"}),
+	}
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.bytes,
+		m.retries,
+		m.limiterAcquired,
+		m.limiterRejected,
+		m.limiterActive,
+		m.rateLimited,
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(config.Path, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	m.server = &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", config.Host, config.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return m
+}
+
+func (m *Metrics) ObserveRequest(method string, code string, duration time.Duration) {
+	m.requests.WithLabelValues(method, code).Inc()
+	m.duration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) AddReceivedBytes(method string, n int) {
+	m.bytes.WithLabelValues(method, "upload").Add(float64(n))
+}
+
+func (m *Metrics) AddSentBytes(method string, n int) {
+	m.bytes.WithLabelValues(method, "download").Add(float64(n))
+}
+
+// RetryHook counts the retries of the named storage, it is meant for the OnRetry hooks of the storages.
+func (m *Metrics) RetryHook(storage string) func(attempt int) {
+	counter := m.retries.WithLabelValues(storage)
+
+	return func(int) {
+		counter.Inc()
+	}
+}
+
+// The limiter functions match the OnAcquire, OnRelease and OnReject hooks of the concurrency interceptor.
+
+func (m *Metrics) LimiterAcquired(_ string, _ string, kind string) {
+	m.limiterAcquired.WithLabelValues(kind).Inc()
+	m.limiterActive.WithLabelValues(kind).Inc()
+}
+
+func (m *Metrics) LimiterReleased(_ string, _ string, kind string) {
+	m.limiterActive.WithLabelValues(kind).Dec()
+}
+
+func (m *Metrics) LimiterRejected(_ string, _ string, kind string) {
+	m.limiterRejected.WithLabelValues(kind).Inc()
+}
+
+func (m *Metrics) RateLimited(_ string, _ string, kind string) {
+	m.rateLimited.WithLabelValues(kind).Inc()
+}
+
+func (m *Metrics) Start() error {
+	if !m.enabled {
+		return nil
+	}
+
+	m.logger.Info("Start: metrics server is starting", zap.String("addr", m.server.Addr))
+
+	err := m.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		m.logger.Error("Start: failed to serve metrics", zap.Error(err))
+		return fmt.Errorf("Start: failed to serve metrics: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Metrics) Stop(ctx context.Context) error {
+	if !m.enabled {
+		return nil
+	}
+
+	err := m.server.Shutdown(ctx)
+	if err != nil {
+		m.logger.Error("Stop: failed to shutdown metrics server", zap.Error(err))
+		return fmt.Errorf("Stop: failed to shutdown metrics server: %w", err)
+	}
+
+	return nil
+}
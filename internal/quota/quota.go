@@ -0,0 +1,23 @@
+package quota
+
+// Config holds the default limits of a tenant, zero means no limit.
+type Config struct {
+	MaxBytes int64 `yaml:"max_bytes" env-default:"0"`
+	MaxFiles int64 `yaml:"max_files" env-default:"0"`
+	// Tenants override the default limits of single tenants.
+	Tenants map[string]Limits `yaml:"tenants"`
+}
+
+type Limits struct {
+	MaxBytes int64 `yaml:"max_bytes"`
+	MaxFiles int64 `yaml:"max_files"`
+}
+
+// For returns the limits of the tenant.
+func (c *Config) For(tenant string) Limits {
+	if limits, ok := c.Tenants[tenant]; ok {
+		return limits
+	}
+
+	return Limits{MaxBytes: c.MaxBytes, MaxFiles: c.MaxFiles}
+}
@@ -7,26 +7,66 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"go.uber.org/zap"
+
+	"fileservice/internal/tlsconfig"
 )
 
 var (
 	ErrNotFound = errors.New("object not found")
 )
 
+// blobPrefix groups deduplicated objects, which are named after the SHA-256 of their content.
+const blobPrefix = "blobs/"
+
+// tenantPrefix keeps the objects of every tenant under a prefix of its own.
+const tenantPrefix = "tenants/"
+
+// BlobKey names the blob object. A hash scoped to a tenant as tenant/sha256 is placed under the prefix of that tenant.
+func BlobKey(hash string) string {
+	if tenant, sum, ok := strings.Cut(hash, "/"); ok {
+		return TenantKey(tenant, blobPrefix+sum)
+	}
+
+	return blobPrefix + hash
+}
+
+func TenantKey(tenant string, key string) string {
+	return tenantPrefix + tenant + "/" + key
+}
+
 func New(ctx context.Context, config Config, logger *zap.Logger) (*Storage, error) {
 	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
 	defer cancel()
 
 	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
 
-	mc, err := minio.New(addr, &minio.Options{
-		Creds: credentials.NewStaticV4(config.User, config.Password, ""),
-	})
+	opts := &minio.Options{
+		Creds:  credentials.NewStaticV4(config.User, config.Password, ""),
+		Secure: config.Secure,
+	}
+
+	if config.Secure && config.CAFile != "" {
+		rootCAs, err := tlsconfig.LoadCertPool(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load minio ca: %w", err)
+		}
+
+		transport, err := minio.DefaultTransport(true)
+		if err != nil {
+			return nil, fmt.Errorf("cannot initialize minio transport: %w", err)
+		}
+
+		transport.TLSClientConfig.RootCAs = rootCAs
+		opts.Transport = transport
+	}
+
+	mc, err := minio.New(addr, opts)
 	if err != nil {
 		return nil, fmt.Errorf("cannot initialize minio client: %w", err)
 	}
@@ -49,26 +89,45 @@ func New(ctx context.Context, config Config, logger *zap.Logger) (*Storage, erro
 
 	return &Storage{
 		mc:          mc,
+		core:        minio.Core{Client: mc},
 		bucketName:  config.BucketName,
 		timeout:     config.Timeout,
 		logger:      logger,
 		maxRetries:  config.MaxRetries,
 		baseBackoff: config.BaseBackoff,
+		partSize:    config.PartSize,
 	}, nil
 }
 
-func (s *Storage) PutObject(ctx context.Context, id string, reader io.Reader, size int64) error {
+// Ping checks that the bucket is reachable and still exists. It is not retried, so the caller sees the current state.
+func (s *Storage) Ping(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (struct{}, error) {
-		_, err := s.mc.PutObject(ctx, s.bucketName, id, reader, size, minio.PutObjectOptions{
-			ContentType: "application/octet-stream",
-		})
-		return struct{}{}, err
+	exists, err := s.mc.BucketExists(ctx, s.bucketName)
+	if err != nil {
+		return fmt.Errorf("Ping: cannot check if bucket exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("Ping: bucket %q does not exist", s.bucketName)
+	}
+
+	return nil
+}
+
+func (s *Storage) PutObject(ctx context.Context, id string, reader io.Reader, size int64, contentType string) error {
+	ctx, span := startSpan(ctx, "PutObject")
+	defer span.End()
+
+	// The reader is a one-shot stream, so the upload is not retried: a second attempt would start mid-stream.
+	// It is not bounded by the storage timeout either, the upload lasts as long as the caller streams the content.
+	_, err := s.mc.PutObject(ctx, s.bucketName, id, reader, size, minio.PutObjectOptions{
+		ContentType: contentType,
+		PartSize:    s.partSize,
 	})
 	if err != nil {
-		s.logger.Error("PutObject: cannot upload file", zap.Error(err))
+		s.logger.Error("PutObject: cannot upload file", zap.String("id", id), zap.Error(err))
+		s.removeIncompleteUpload(ctx, id)
 		return fmt.Errorf("PutObject: cannot upload file: %w", err)
 	}
 
@@ -76,9 +135,40 @@ func (s *Storage) PutObject(ctx context.Context, id string, reader io.Reader, si
 	return nil
 }
 
-func (s *Storage) GetObject(ctx context.Context, id string) (io.ReadCloser, error) {
-	object, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (*minio.Object, error) {
-		return s.mc.GetObject(ctx, s.bucketName, id, minio.GetObjectOptions{})
+// removeIncompleteUpload drops the parts of an aborted multipart upload.
+// A fresh context is used because the upload usually fails due to the canceled one.
+func (s *Storage) removeIncompleteUpload(ctx context.Context, id string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+	defer cancel()
+
+	err := s.mc.RemoveIncompleteUpload(ctx, s.bucketName, id)
+	if err != nil {
+		s.logger.Warn("removeIncompleteUpload: cannot remove incomplete upload", zap.String("id", id), zap.Error(err))
+	}
+}
+
+// GetObject reads length bytes of the object starting from offset, zero length reads up to the end of the object.
+func (s *Storage) GetObject(ctx context.Context, id string, offset int64, length int64) (io.ReadCloser, error) {
+	ctx, span := startSpan(ctx, "GetObject")
+	defer span.End()
+
+	opts := minio.GetObjectOptions{}
+
+	if offset > 0 || length > 0 {
+		end := int64(0)
+		if length > 0 {
+			end = offset + length - 1
+		}
+
+		err := opts.SetRange(offset, end)
+		if err != nil {
+			s.logger.Error("GetObject: invalid range", zap.String("id", id), zap.Error(err))
+			return nil, fmt.Errorf("GetObject: invalid range: %w", err)
+		}
+	}
+
+	object, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*minio.Object, error) {
+		return s.mc.GetObject(ctx, s.bucketName, id, opts)
 	})
 	if err != nil {
 		resp := minio.ToErrorResponse(err)
@@ -95,17 +185,131 @@ func (s *Storage) GetObject(ctx context.Context, id string) (io.ReadCloser, erro
 	return object, nil
 }
 
-func withRetry[T any](ctx context.Context, maxRetries int, baseBackoff time.Duration, logger *zap.Logger, fn func() (T, error)) (T, error) {
+func (s *Storage) StatObject(ctx context.Context, id string) (int64, error) {
+	ctx, span := startSpan(ctx, "StatObject")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	info, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (minio.ObjectInfo, error) {
+		info, err := s.mc.StatObject(ctx, s.bucketName, id, minio.StatObjectOptions{})
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return info, ErrNotFound
+		}
+
+		return info, err
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			s.logger.Warn("StatObject: object not found", zap.String("id", id))
+			return 0, fmt.Errorf("StatObject: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("StatObject: failed to stat object", zap.String("id", id), zap.Error(err))
+		return 0, fmt.Errorf("StatObject: failed to stat object: %w", err)
+	}
+
+	s.logger.Info("StatObject: successfully stat object", zap.String("id", id))
+	return info.Size, nil
+}
+
+// CopyObject makes a server-side copy, so the content does not pass through the service.
+func (s *Storage) CopyObject(ctx context.Context, srcID string, dstID string) error {
+	ctx, span := startSpan(ctx, "CopyObject")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (minio.UploadInfo, error) {
+		info, err := s.mc.ComposeObject(ctx,
+			minio.CopyDestOptions{Bucket: s.bucketName, Object: dstID},
+			minio.CopySrcOptions{Bucket: s.bucketName, Object: srcID},
+		)
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return info, ErrNotFound
+		}
+
+		return info, err
+	})
+	if err != nil {
+		s.logger.Error("CopyObject: failed to copy object", zap.String("src", srcID), zap.String("dst", dstID), zap.Error(err))
+		return fmt.Errorf("CopyObject: failed to copy object: %w", err)
+	}
+
+	s.logger.Info("CopyObject: successfully copied object", zap.String("src", srcID), zap.String("dst", dstID))
+	return nil
+}
+
+func (s *Storage) RemoveObject(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "RemoveObject")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (struct{}, error) {
+		err := s.mc.RemoveObject(ctx, s.bucketName, id, minio.RemoveObjectOptions{})
+		return struct{}{}, err
+	})
+	if err != nil {
+		s.logger.Error("RemoveObject: failed to remove object", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("RemoveObject: failed to remove object: %w", err)
+	}
+
+	s.logger.Info("RemoveObject: successfully removed object", zap.String("id", id))
+	return nil
+}
+
+// WalkObjects calls fn for every object of the bucket and stops at the first error.
+// The listing is not retried, since a restart would visit the same objects again.
+func (s *Storage) WalkObjects(ctx context.Context, fn func(object Object) error) error {
+	ctx, span := startSpan(ctx, "WalkObjects")
+	defer span.End()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for info := range s.mc.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Recursive: true}) {
+		if info.Err != nil {
+			s.logger.Error("WalkObjects: failed to list objects", zap.Error(info.Err))
+			return fmt.Errorf("WalkObjects: failed to list objects: %w", info.Err)
+		}
+
+		err := fn(Object{Key: info.Key, Size: info.Size, LastModified: info.LastModified})
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func withRetry[T any](
+	ctx context.Context,
+	maxRetries int,
+	baseBackoff time.Duration,
+	logger *zap.Logger,
+	onRetry func(attempt int),
+	fn func() (T, error),
+) (T, error) {
 	var zero T
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
+		attempt := startAttempt(ctx, i+1)
 		res, err := fn()
+		endAttempt(attempt, err)
+
 		switch {
 		case err == nil:
 			return res, nil
-		case errors.Is(err, ErrNotFound):
-			return zero, ErrNotFound
+		case errors.Is(err, ErrNotFound),
+			errors.Is(err, ErrUploadNotFound),
+			errors.Is(err, ErrPartTooSmall):
+
+			return zero, err
 		}
 
 		lastErr = err
@@ -122,11 +326,18 @@ func withRetry[T any](ctx context.Context, maxRetries int, baseBackoff time.Dura
 		case <-time.After(pause):
 		case <-ctx.Done():
 			logger.Error("withRetry: context canceled", zap.Int("attempts", i+1), zap.Duration("backoff", baseBackoff))
+			failSpan(ctx, ctx.Err())
 			return zero, ctx.Err()
 		}
 
 		logger.Warn("withRetry: retrying", zap.Int("attempt", i+1), zap.Duration("backoff", pause))
+		if onRetry != nil {
+			onRetry(i + 1)
+		}
 	}
 
-	return zero, fmt.Errorf("withRetry: all retries failed, lastErr: %w", lastErr)
+	err := fmt.Errorf("withRetry: all retries failed, lastErr: %w", lastErr)
+	failSpan(ctx, err)
+
+	return zero, err
 }
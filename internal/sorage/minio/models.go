@@ -16,13 +16,28 @@ type Config struct {
 	Timeout     time.Duration `yaml:"timeout" env-required:"true"`
 	MaxRetries  int           `yaml:"max_retries" env-required:"true"`
 	BaseBackoff time.Duration `yaml:"base_backoff" env-required:"true"`
+	PartSize    uint64        `yaml:"part_size" env-default:"16777216"`
+	Secure      bool          `yaml:"secure" env-default:"false"`
+	// CAFile verifies the MinIO certificate against a private CA instead of the system roots.
+	CAFile string `yaml:"ca_file"`
+}
+
+// Object describes an object found while walking the bucket.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
 }
 
 type Storage struct {
 	mc          *minio.Client
+	core        minio.Core
 	bucketName  string
 	timeout     time.Duration
 	logger      *zap.Logger
 	maxRetries  int
 	baseBackoff time.Duration
+	partSize    uint64
+	// OnRetry is called before every retry of a failed call, it has to be set before the storage is used.
+	OnRetry func(attempt int)
 }
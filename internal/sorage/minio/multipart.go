@@ -0,0 +1,130 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUploadNotFound = errors.New("multipart upload not found")
+	ErrPartTooSmall   = errors.New("multipart upload part is too small")
+)
+
+type Part struct {
+	Number int
+	ETag   string
+}
+
+func (s *Storage) NewMultipartUpload(ctx context.Context, id string, contentType string) (string, error) {
+	ctx, span := startSpan(ctx, "NewMultipartUpload")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	uploadID, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (string, error) {
+		return s.core.NewMultipartUpload(ctx, s.bucketName, id, minio.PutObjectOptions{
+			ContentType: contentType,
+		})
+	})
+	if err != nil {
+		s.logger.Error("NewMultipartUpload: failed to initiate upload", zap.String("id", id), zap.Error(err))
+		return "", fmt.Errorf("NewMultipartUpload: failed to initiate upload: %w", err)
+	}
+
+	s.logger.Info("NewMultipartUpload: successfully initiated upload", zap.String("id", id), zap.String("upload_id", uploadID))
+	return uploadID, nil
+}
+
+// PutObjectPart is neither retried nor bounded by the storage timeout for the same reasons as PutObject.
+func (s *Storage) PutObjectPart(ctx context.Context, id string, uploadID string, number int, reader io.Reader, size int64) (string, error) {
+	ctx, span := startSpan(ctx, "PutObjectPart")
+	defer span.End()
+
+	part, err := s.core.PutObjectPart(ctx, s.bucketName, id, uploadID, number, reader, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+			s.logger.Warn("PutObjectPart: upload not found", zap.String("id", id), zap.String("upload_id", uploadID))
+			return "", fmt.Errorf("PutObjectPart: %w: %s", ErrUploadNotFound, uploadID)
+		}
+
+		s.logger.Error("PutObjectPart: failed to upload part",
+			zap.String("id", id),
+			zap.Int("part_number", number),
+			zap.Error(err),
+		)
+		return "", fmt.Errorf("PutObjectPart: failed to upload part: %w", err)
+	}
+
+	s.logger.Info("PutObjectPart: successfully uploaded part", zap.String("id", id), zap.Int("part_number", number))
+	return part.ETag, nil
+}
+
+func (s *Storage) CompleteMultipartUpload(ctx context.Context, id string, uploadID string, parts []Part) error {
+	ctx, span := startSpan(ctx, "CompleteMultipartUpload")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{
+			PartNumber: part.Number,
+			ETag:       part.ETag,
+		})
+	}
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (minio.UploadInfo, error) {
+		info, err := s.core.CompleteMultipartUpload(ctx, s.bucketName, id, uploadID, completeParts, minio.PutObjectOptions{})
+		switch minio.ToErrorResponse(err).Code {
+		case "EntityTooSmall":
+			return info, ErrPartTooSmall
+		case "NoSuchUpload":
+			return info, ErrUploadNotFound
+		}
+
+		return info, err
+	})
+	if err != nil {
+		s.logger.Error("CompleteMultipartUpload: failed to complete upload", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("CompleteMultipartUpload: failed to complete upload: %w", err)
+	}
+
+	s.logger.Info("CompleteMultipartUpload: successfully completed upload", zap.String("id", id))
+	return nil
+}
+
+func (s *Storage) AbortMultipartUpload(ctx context.Context, id string, uploadID string) error {
+	ctx, span := startSpan(ctx, "AbortMultipartUpload")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (struct{}, error) {
+		err := s.core.AbortMultipartUpload(ctx, s.bucketName, id, uploadID)
+		if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+			return struct{}{}, ErrUploadNotFound
+		}
+
+		return struct{}{}, err
+	})
+	if err != nil {
+		if errors.Is(err, ErrUploadNotFound) {
+			s.logger.Warn("AbortMultipartUpload: upload not found", zap.String("id", id), zap.String("upload_id", uploadID))
+			return fmt.Errorf("AbortMultipartUpload: %w: %s", ErrUploadNotFound, uploadID)
+		}
+
+		s.logger.Error("AbortMultipartUpload: failed to abort upload", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("AbortMultipartUpload: failed to abort upload: %w", err)
+	}
+
+	s.logger.Info("AbortMultipartUpload: successfully aborted upload", zap.String("id", id), zap.String("upload_id", uploadID))
+	return nil
+}
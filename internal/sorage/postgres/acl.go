@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// GrantAccess adds the permissions to the acl of the file, already granted ones are kept as they are.
+func (s *Storage) GrantAccess(ctx context.Context, id string, granteeType string, grantee string, permissions []string) error {
+	ctx, span := startSpan(ctx, "GrantAccess")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryGrantAccess, id, granteeType, grantee, permissions, time.Now().UTC())
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("GrantAccess: failed to grant access", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("GrantAccess: failed to grant access: %w", err)
+	}
+
+	s.logger.Info("GrantAccess: successfully granted access",
+		zap.String("id", id),
+		zap.String("grantee_type", granteeType),
+		zap.String("grantee", grantee),
+		zap.Strings("permissions", permissions),
+	)
+	return nil
+}
+
+// RevokeAccess removes the permissions of the grantee, empty permissions remove all of them.
+func (s *Storage) RevokeAccess(ctx context.Context, id string, granteeType string, grantee string, permissions []string) error {
+	ctx, span := startSpan(ctx, "RevokeAccess")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryRevokeAccess, id, granteeType, grantee, permissions)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("RevokeAccess: failed to revoke access", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("RevokeAccess: failed to revoke access: %w", err)
+	}
+
+	s.logger.Info("RevokeAccess: successfully revoked access",
+		zap.String("id", id),
+		zap.String("grantee_type", granteeType),
+		zap.String("grantee", grantee),
+		zap.Strings("permissions", permissions),
+	)
+	return nil
+}
+
+func (s *Storage) ListAccess(ctx context.Context, id string) ([]*AccessEntry, error) {
+	ctx, span := startSpan(ctx, "ListAccess")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, queryListAccess, id)
+		return rows, err
+	})
+	if err != nil {
+		s.logger.Error("ListAccess: failed to get acl", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("ListAccess: failed to get acl: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*AccessEntry
+
+	for rows.Next() {
+		entry := &AccessEntry{}
+
+		err = rows.Scan(&entry.GranteeType, &entry.Grantee, &entry.Permission, &entry.CreatedAt)
+		if err != nil {
+			s.logger.Error("ListAccess: failed to scan acl", zap.String("id", id), zap.Error(err))
+			return nil, fmt.Errorf("ListAccess: failed to scan acl: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		s.logger.Error("ListAccess: failed to scan acl", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("ListAccess: failed to scan acl: %w", err)
+	}
+
+	return entries, nil
+}
+
+// HasPermission reports whether the acl of the file grants the permission to the accessor or to one of its groups.
+// The owner is not checked here.
+func (s *Storage) HasPermission(ctx context.Context, id string, accessor *Accessor, permission string) (bool, error) {
+	ctx, span := startSpan(ctx, "HasPermission")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	allowed, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (bool, error) {
+		var allowed bool
+		err := s.pool.QueryRow(ctx, queryHasPermission, id, permission,
+			GranteeUser, accessor.Subject, GranteeGroup, accessor.Groups).Scan(&allowed)
+		return allowed, err
+	})
+	if err != nil {
+		s.logger.Error("HasPermission: failed to check acl", zap.String("id", id), zap.Error(err))
+		return false, fmt.Errorf("HasPermission: failed to check acl: %w", err)
+	}
+
+	return allowed, nil
+}
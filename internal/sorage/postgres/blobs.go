@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// AcquireBlob adds a reference to the blob, creating it if needed, and returns the new reference count.
+func (s *Storage) AcquireBlob(ctx context.Context, hash string, size int64) (int64, error) {
+	ctx, span := startSpan(ctx, "AcquireBlob")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	refCount, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (int64, error) {
+		var refCount int64
+		err := s.pool.QueryRow(ctx, queryAcquireBlob, hash, size, time.Now().UTC()).Scan(&refCount)
+		return refCount, err
+	})
+	if err != nil {
+		s.logger.Error("AcquireBlob: failed to acquire blob", zap.String("hash", hash), zap.Error(err))
+		return 0, fmt.Errorf("AcquireBlob: failed to acquire blob: %w", err)
+	}
+
+	s.logger.Info("AcquireBlob: successfully acquired blob", zap.String("hash", hash), zap.Int64("ref_count", refCount))
+	return refCount, nil
+}
+
+// ReleaseBlob drops a reference to the blob and reports whether it was the last one.
+func (s *Storage) ReleaseBlob(ctx context.Context, hash string) (bool, error) {
+	ctx, span := startSpan(ctx, "ReleaseBlob")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	last, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (bool, error) {
+		var last bool
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			var err error
+			last, err = releaseBlob(ctx, tx, hash)
+			return err
+		})
+
+		return last, err
+	})
+	if err != nil {
+		s.logger.Error("ReleaseBlob: failed to release blob", zap.String("hash", hash), zap.Error(err))
+		return false, fmt.Errorf("ReleaseBlob: failed to release blob: %w", err)
+	}
+
+	s.logger.Info("ReleaseBlob: successfully released blob", zap.String("hash", hash), zap.Bool("last", last))
+	return last, nil
+}
+
+// AttachBlob points the file stored under uploadedKey to the blob, together with its version stored there.
+// A file that has moved to another object since is reported as missing, so its newer content is never replaced.
+func (s *Storage) AttachBlob(ctx context.Context, id string, uploadedKey string, hash string, objectKey string) error {
+	ctx, span := startSpan(ctx, "AttachBlob")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		var tag pgconn.CommandTag
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			var err error
+
+			tag, err = tx.Exec(ctx, queryAttachBlob, objectKey, hash, id, uploadedKey)
+			if err != nil || tag.RowsAffected() == 0 {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, queryAttachVersionBlob, objectKey, hash, id, uploadedKey)
+			return err
+		})
+
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("AttachBlob: failed to attach blob", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("AttachBlob: failed to attach blob: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("AttachBlob: file not found", zap.String("id", id))
+		return fmt.Errorf("AttachBlob: %w: %s", ErrNotFound, id)
+	}
+
+	s.logger.Info("AttachBlob: successfully attached blob", zap.String("id", id), zap.String("hash", hash))
+	return nil
+}
+
+func releaseBlob(ctx context.Context, tx pgx.Tx, hash string) (bool, error) {
+	var refCount int64
+
+	err := tx.QueryRow(ctx, queryReleaseBlob, hash).Scan(&refCount)
+	if err != nil {
+		return false, fmt.Errorf("releaseBlob: failed to decrement reference count: %w", err)
+	}
+
+	if refCount > 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, queryDeleteUnusedBlob, hash)
+	if err != nil {
+		return false, fmt.Errorf("releaseBlob: failed to delete unused blob: %w", err)
+	}
+
+	return true, nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -17,8 +19,16 @@ import (
 )
 
 const (
-	statusPending = "pending"
-	statusSuccess = "success"
+	StatusPending  = "pending"
+	StatusSuccess  = "success"
+	StatusDeleting = "deleting"
+	StatusTrashed  = "trashed"
+)
+
+var (
+	ErrNotFound      = errors.New("file not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrQuotaExceeded = errors.New("quota exceeded")
 )
 
 func New(ctx context.Context, config *Config, logger *zap.Logger) (*Storage, error) {
@@ -46,15 +56,60 @@ func New(ctx context.Context, config *Config, logger *zap.Logger) (*Storage, err
 	}, nil
 }
 
-func (s *Storage) SaveFileInfo(ctx context.Context, id string, fileName string, createdAt time.Time, updatedAt time.Time) error {
+// SaveFileInfo inserts a pending file and counts it in the usage of its tenant.
+// ErrQuotaExceeded is returned when the tenant already has maxFiles files, zero maxFiles means no limit,
+// and ErrAlreadyExists when a file with the id is saved already.
+func (s *Storage) SaveFileInfo(ctx context.Context, file *PendingFile, maxFiles int64) error {
+	ctx, span := startSpan(ctx, "SaveFileInfo")
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (pgconn.CommandTag, error) {
-		tag, err := s.pool.Exec(ctx, querySaveFileInfo, id, fileName, createdAt, updatedAt, statusPending)
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		var tag pgconn.CommandTag
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			var files int64
+
+			err := tx.QueryRow(ctx, queryReserveFile, file.Tenant, maxFiles).Scan(&files)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrQuotaExceeded
+				}
+
+				return err
+			}
+
+			tag, err = tx.Exec(ctx, querySaveFileInfo,
+				file.ID,
+				file.Name,
+				file.ContentType,
+				file.CreatedAt,
+				file.UpdatedAt,
+				StatusPending,
+				file.ObjectKey,
+				file.ExpiresAt,
+				file.Owner,
+				file.Tenant,
+			)
+			return err
+		})
+
 		return tag, err
 	})
 	if err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			s.logger.Warn("Save: file quota exceeded", zap.String("tenant", file.Tenant), zap.Int64("max_files", maxFiles))
+			return fmt.Errorf("Save: %w: files", ErrQuotaExceeded)
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			s.logger.Warn("Save: file already exists", zap.String("file_id", file.ID))
+			return fmt.Errorf("Save: %w: %s", ErrAlreadyExists, file.ID)
+		}
+
 		s.logger.Error("Save: failed to insert file", zap.Error(err))
 		return err
 	}
@@ -63,19 +118,47 @@ func (s *Storage) SaveFileInfo(ctx context.Context, id string, fileName string,
 		return fmt.Errorf("Save: no rows affected")
 	}
 
-	s.logger.Info("Save: successfully inserted file", zap.String("file_id", id))
+	s.logger.Info("Save: successfully inserted file", zap.String("file_id", file.ID))
 	return nil
 }
 
-func (s *Storage) SetSuccessStatus(ctx context.Context, id string) error {
+// SetSuccessStatus adds the size of the uploaded content to the usage of the tenant, ErrQuotaExceeded is returned
+// when it does not fit into maxBytes. Zero maxBytes means no limit.
+func (s *Storage) SetSuccessStatus(ctx context.Context, id string, size int64, checksum string, crc32c string, maxBytes int64) error {
+	ctx, span := startSpan(ctx, "SetSuccessStatus")
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (pgconn.CommandTag, error) {
-		tag, err := s.pool.Exec(ctx, querySetSuccessStatus, statusSuccess, id)
+	// The uploaded content becomes the first version of the file in the same transaction.
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		var tag pgconn.CommandTag
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			var err error
+
+			tag, err = tx.Exec(ctx, querySetSuccessStatus, StatusSuccess, size, checksum, crc32c, id)
+			if err != nil || tag.RowsAffected() == 0 {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, queryInsertFirstVersion, id)
+			if err != nil {
+				return err
+			}
+
+			return addUsageBytes(ctx, tx, id, size, maxBytes)
+		})
+
 		return tag, err
 	})
 	if err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			s.logger.Warn("SetSuccessStatus: byte quota exceeded", zap.String("id", id), zap.Int64("max_bytes", maxBytes))
+			return fmt.Errorf("SetSuccessStatus: %w: bytes", ErrQuotaExceeded)
+		}
+
 		s.logger.Error("SetSuccessStatus: failed to set success status", zap.Error(err))
 		return fmt.Errorf("SetSuccessStatus: failed to set success status: %w", err)
 	}
@@ -88,12 +171,22 @@ func (s *Storage) SetSuccessStatus(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *Storage) ListFilesInfo(ctx context.Context, limit int64, offset int64) ([]*fileservice.FileInfo, error) {
+// ListFilesInfo returns a page of files matching the filter. With a cursor the page starts right after it and offset is ignored.
+func (s *Storage) ListFilesInfo(ctx context.Context, filter *ListFilter) ([]*fileservice.FileInfo, error) {
+	ctx, span := startSpan(ctx, "ListFilesInfo")
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (pgx.Rows, error) {
-		rows, err := s.pool.Query(ctx, queryListFilesInfo, limit, offset)
+	query, args, err := buildListFilesQuery(filter)
+	if err != nil {
+		s.logger.Error("ListFilesInfo: failed to build query", zap.Error(err))
+		return nil, fmt.Errorf("ListFilesInfo: failed to build query: %w", err)
+	}
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, query, args...)
 		return rows, err
 	})
 	if err != nil {
@@ -102,14 +195,18 @@ func (s *Storage) ListFilesInfo(ctx context.Context, limit int64, offset int64)
 	}
 
 	defer rows.Close()
-	files := make([]*fileservice.FileInfo, 0, limit)
+	files := make([]*fileservice.FileInfo, 0, filter.Limit)
 
 	for rows.Next() {
 		file := &fileservice.FileInfo{}
 		var tempCreatedAt time.Time
 		var tempUpdatedAt time.Time
 
-		err = rows.Scan(&file.Name, &tempCreatedAt, &tempUpdatedAt)
+		var tempDeletedAt *time.Time
+		var tempExpiresAt *time.Time
+
+		err = rows.Scan(&file.Id, &file.Name, &file.Status, &file.Size, &file.ContentType, &file.Version,
+			&tempCreatedAt, &tempUpdatedAt, &tempDeletedAt, &tempExpiresAt, &file.Owner)
 		if err != nil {
 			s.logger.Error("ListFilesInfo: failed to scan files", zap.Error(err))
 			return nil, fmt.Errorf("ListFilesInfo: failed to scan files: %w", err)
@@ -117,6 +214,12 @@ func (s *Storage) ListFilesInfo(ctx context.Context, limit int64, offset int64)
 
 		file.CreatedAt = timestamppb.New(tempCreatedAt)
 		file.UpdatedAt = timestamppb.New(tempUpdatedAt)
+		if tempDeletedAt != nil {
+			file.DeletedAt = timestamppb.New(*tempDeletedAt)
+		}
+		if tempExpiresAt != nil {
+			file.ExpiresAt = timestamppb.New(*tempExpiresAt)
+		}
 
 		files = append(files, file)
 	}
@@ -131,65 +234,449 @@ func (s *Storage) ListFilesInfo(ctx context.Context, limit int64, offset int64)
 	return files, nil
 }
 
-func (s *Storage) DeleteFileInfo(ctx context.Context, id string) error {
+// DeleteFileInfo removes the file with all its versions and returns the blobs which are no longer used by anyone.
+// Every version holds its own blob reference, a file without versions is still pending and holds the reference itself.
+// The file and the size of its versions are subtracted from the usage of the tenant.
+func (s *Storage) DeleteFileInfo(ctx context.Context, id string) ([]string, error) {
+	ctx, span := startSpan(ctx, "DeleteFileInfo")
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (pgconn.CommandTag, error) {
-		tag, err := s.pool.Exec(ctx, queryDeleteFileInfo, id)
-		return tag, err
+	unusedBlobs, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() ([]string, error) {
+		var unusedBlobs []string
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			rows, err := tx.Query(ctx, queryDeleteFileVersions, id)
+			if err != nil {
+				return err
+			}
+
+			var blobHashes []string
+			var size int64
+
+			for rows.Next() {
+				var blobHash string
+				var versionSize int64
+
+				err = rows.Scan(&blobHash, &versionSize)
+				if err != nil {
+					rows.Close()
+					return err
+				}
+
+				blobHashes = append(blobHashes, blobHash)
+				size += versionSize
+			}
+
+			err = rows.Err()
+			if err != nil {
+				return err
+			}
+
+			var fileBlobHash, tenant string
+
+			err = tx.QueryRow(ctx, queryDeleteFileInfo, id).Scan(&fileBlobHash, &tenant)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, queryReleaseUsage, tenant, size)
+			if err != nil {
+				return err
+			}
+
+			if len(blobHashes) == 0 {
+				blobHashes = append(blobHashes, fileBlobHash)
+			}
+
+			for _, blobHash := range blobHashes {
+				if blobHash == "" {
+					continue
+				}
+
+				last, err := releaseBlob(ctx, tx, blobHash)
+				if err != nil {
+					return err
+				}
+
+				if last {
+					unusedBlobs = append(unusedBlobs, blobHash)
+				}
+			}
+
+			return nil
+		})
+
+		return unusedBlobs, err
 	})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("Delete: file not found", zap.String("id", id))
+			return nil, fmt.Errorf("Delete: %w: %s", ErrNotFound, id)
+		}
+
 		s.logger.Error("Delete: failed to delete file info", zap.String("id", id), zap.Error(err))
-		return fmt.Errorf("Delete: failed to delete file info")
+		return nil, fmt.Errorf("Delete: failed to delete file info: %w", err)
+	}
+
+	s.logger.Info("Delete: successfully deleted file info", zap.String("id", id))
+	return unusedBlobs, nil
+}
+
+func (s *Storage) GetFileInfo(ctx context.Context, id string) (*File, error) {
+	ctx, span := startSpan(ctx, "GetFileInfo")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	file, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*File, error) {
+		return scanFile(s.pool.QueryRow(ctx, queryGetFileInfo, id))
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("GetFileInfo: file not found", zap.String("id", id))
+			return nil, fmt.Errorf("GetFileInfo: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("GetFileInfo: failed to get file info", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("GetFileInfo: failed to get file info: %w", err)
+	}
+
+	s.logger.Info("GetFileInfo: successfully retrieved file info", zap.String("id", id))
+	return file, nil
+}
+
+// ListStaleFiles returns files which have been in the status since before the given time, the oldest first.
+func (s *Storage) ListStaleFiles(ctx context.Context, status string, before time.Time, limit int) ([]*File, error) {
+	ctx, span := startSpan(ctx, "ListStaleFiles")
+	defer span.End()
+
+	files, err := s.queryFiles(ctx, queryListStaleFiles, status, before, limit)
+	if err != nil {
+		s.logger.Error("ListStaleFiles: failed to get files", zap.String("status", status), zap.Error(err))
+		return nil, fmt.Errorf("ListStaleFiles: failed to get files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ListTrashedFiles returns files moved to the trash before the given time, the oldest first.
+func (s *Storage) ListTrashedFiles(ctx context.Context, before time.Time, limit int) ([]*File, error) {
+	ctx, span := startSpan(ctx, "ListTrashedFiles")
+	defer span.End()
+
+	files, err := s.queryFiles(ctx, queryListTrashedFiles, StatusTrashed, before, limit)
+	if err != nil {
+		s.logger.Error("ListTrashedFiles: failed to get files", zap.Error(err))
+		return nil, fmt.Errorf("ListTrashedFiles: failed to get files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ListExpiredFiles returns files in one of the statuses which expired before the given time, the oldest first.
+func (s *Storage) ListExpiredFiles(ctx context.Context, statuses []string, before time.Time, limit int) ([]*File, error) {
+	ctx, span := startSpan(ctx, "ListExpiredFiles")
+	defer span.End()
+
+	files, err := s.queryFiles(ctx, queryListExpiredFiles, statuses, before, limit)
+	if err != nil {
+		s.logger.Error("ListExpiredFiles: failed to get files", zap.Error(err))
+		return nil, fmt.Errorf("ListExpiredFiles: failed to get files: %w", err)
+	}
+
+	return files, nil
+}
+
+func (s *Storage) queryFiles(ctx context.Context, query string, args ...any) ([]*File, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, query, args...)
+		return rows, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []*File
+
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, rows.Err()
+}
+
+// TrashFile moves an uploaded file to the trash.
+func (s *Storage) TrashFile(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "TrashFile")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryTrashFile, StatusTrashed, time.Now().UTC(), id, StatusSuccess)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("TrashFile: failed to trash file", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("TrashFile: failed to trash file: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("TrashFile: file not found", zap.String("id", id))
+		return fmt.Errorf("TrashFile: %w: %s", ErrNotFound, id)
 	}
 
+	s.logger.Info("TrashFile: successfully trashed file", zap.String("id", id))
+	return nil
+}
+
+// RestoreFile takes a file out of the trash.
+func (s *Storage) RestoreFile(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "RestoreFile")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryRestoreFile, StatusSuccess, id, StatusTrashed)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("RestoreFile: failed to restore file", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("RestoreFile: failed to restore file: %w", err)
+	}
 	if tag.RowsAffected() == 0 {
-		s.logger.Error("SetSuccessStatus: no rows affected")
-		return fmt.Errorf("SetSuccessStatus: no rows affected")
+		s.logger.Warn("RestoreFile: file not found in trash", zap.String("id", id))
+		return fmt.Errorf("RestoreFile: %w: %s", ErrNotFound, id)
 	}
 
-	s.logger.Info("Delete: successfully deleted file info", zap.String("id", id))
+	s.logger.Info("RestoreFile: successfully restored file", zap.String("id", id))
 	return nil
 }
 
-func (s *Storage) GetFileName(ctx context.Context, id string) (string, error) {
+// ExistingObjectKeys returns the subset of keys referenced by files.
+func (s *Storage) ExistingObjectKeys(ctx context.Context, keys []string) (map[string]struct{}, error) {
+	ctx, span := startSpan(ctx, "ExistingObjectKeys")
+	defer span.End()
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	var fileName string
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, queryExistingObjectKeys, keys)
+		return rows, err
+	})
+	if err != nil {
+		s.logger.Error("ExistingObjectKeys: failed to get object keys", zap.Error(err))
+		return nil, fmt.Errorf("ExistingObjectKeys: failed to get object keys: %w", err)
+	}
 
-	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, func() (struct{}, error) {
-		err := s.pool.QueryRow(ctx, queryGetFIleName, id).Scan(&fileName)
-		return struct{}{}, err
+	existing, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		s.logger.Error("ExistingObjectKeys: failed to scan object keys", zap.Error(err))
+		return nil, fmt.Errorf("ExistingObjectKeys: failed to scan object keys: %w", err)
+	}
+
+	result := make(map[string]struct{}, len(existing))
+	for _, key := range existing {
+		result[key] = struct{}{}
+	}
+
+	return result, nil
+}
+
+func scanFile(row pgx.Row) (*File, error) {
+	file := &File{Info: &fileservice.FileInfo{}}
+	var tempCreatedAt time.Time
+	var tempUpdatedAt time.Time
+	var tempDeletedAt *time.Time
+	var tempExpiresAt *time.Time
+
+	err := row.Scan(
+		&file.Info.Id,
+		&file.Info.Name,
+		&file.Info.ContentType,
+		&file.Info.Size,
+		&file.Info.Checksum,
+		&file.Info.Crc32C,
+		&file.Info.Status,
+		&file.Info.Version,
+		&tempCreatedAt,
+		&tempUpdatedAt,
+		&tempDeletedAt,
+		&tempExpiresAt,
+		&file.ObjectKey,
+		&file.BlobHash,
+		&file.Info.Owner,
+		&file.Tenant,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Info.CreatedAt = timestamppb.New(tempCreatedAt)
+	file.Info.UpdatedAt = timestamppb.New(tempUpdatedAt)
+	if tempDeletedAt != nil {
+		file.Info.DeletedAt = timestamppb.New(*tempDeletedAt)
+	}
+	if tempExpiresAt != nil {
+		file.Info.ExpiresAt = timestamppb.New(*tempExpiresAt)
+	}
+
+	return file, nil
+}
+
+// TouchPendingFile moves updated_at of a pending file forward, so the reconciler does not take an upload in progress for a stale one.
+func (s *Storage) TouchPendingFile(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "TouchPendingFile")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryTouchPendingFile, time.Now().UTC(), id, StatusPending)
+		return tag, err
 	})
 	if err != nil {
-		s.logger.Error("GetFileName: failed to get file info", zap.String("id", id), zap.Error(err))
-		return "", fmt.Errorf("GetFileName: failed to get file info: %w", err)
+		s.logger.Error("TouchPendingFile: failed to touch pending file", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("TouchPendingFile: failed to touch pending file: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("TouchPendingFile: pending file not found", zap.String("id", id))
+		return fmt.Errorf("TouchPendingFile: %w: %s", ErrNotFound, id)
 	}
 
-	s.logger.Info("GetFileName: successfully retrieved file info", zap.String("id", id))
-	return fileName, nil
+	return nil
+}
+
+// MarkDeleting moves a file in one of the given statuses to deleting, a file already being deleted is accepted as well.
+func (s *Storage) MarkDeleting(ctx context.Context, id string, from ...string) error {
+	ctx, span := startSpan(ctx, "MarkDeleting")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryMarkDeleting, StatusDeleting, time.Now().UTC(), id, from)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("MarkDeleting: failed to mark file as deleting", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("MarkDeleting: failed to mark file as deleting: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("MarkDeleting: file not found", zap.String("id", id))
+		return fmt.Errorf("MarkDeleting: %w: %s", ErrNotFound, id)
+	}
+
+	s.logger.Info("MarkDeleting: successfully marked file as deleting", zap.String("id", id))
+	return nil
+}
+
+func (s *Storage) SetChecksums(ctx context.Context, id string, checksum string, crc32c string) error {
+	ctx, span := startSpan(ctx, "SetChecksums")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		var tag pgconn.CommandTag
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			var err error
+
+			tag, err = tx.Exec(ctx, querySetChecksums, checksum, crc32c, id)
+			if err != nil || tag.RowsAffected() == 0 {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, querySetVersionChecksums, checksum, crc32c, id)
+			return err
+		})
+
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("SetChecksums: failed to set checksums", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("SetChecksums: failed to set checksums: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("SetChecksums: file not found", zap.String("id", id))
+		return fmt.Errorf("SetChecksums: %w: %s", ErrNotFound, id)
+	}
+
+	s.logger.Info("SetChecksums: successfully set checksums", zap.String("id", id))
+	return nil
+}
+
+// Ping checks that the database is reachable. It is not retried, so the caller sees the current state.
+func (s *Storage) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := s.pool.Ping(ctx)
+	if err != nil {
+		return fmt.Errorf("Ping: failed to ping postgres: %w", err)
+	}
+
+	return nil
 }
 
 func (s *Storage) Close() {
 	s.pool.Close()
 }
 
-func withRetry[T any](ctx context.Context, maxRetries int, baseBackoff time.Duration, logger *zap.Logger, fn func() (T, error)) (T, error) {
+func withRetry[T any](
+	ctx context.Context,
+	maxRetries int,
+	baseBackoff time.Duration,
+	logger *zap.Logger,
+	onRetry func(attempt int),
+	fn func() (T, error),
+) (T, error) {
 	var zero T
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
+		attempt := startAttempt(ctx, i+1)
 		res, err := fn()
-		if err == nil {
+		endAttempt(attempt, err)
+
+		switch {
+		case err == nil:
 			return res, nil
+		case errors.Is(err, pgx.ErrNoRows), errors.Is(err, ErrQuotaExceeded):
+			return zero, err
 		}
 
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
-			if pgErr.Code == "42P01" {
+			// An id that is not a uuid cannot match any row.
+			if pgErr.Code == invalidTextRepresentation {
+				logger.Warn("withRetry: invalid id", zap.Error(err))
+				return zero, fmt.Errorf("%w: %w", ErrNotFound, err)
+			}
+
+			if pgErr.Code == "42P01" || pgErr.Code == uniqueViolation {
 				logger.Error("withRetry: non-retryable Postgres error", zap.Error(err))
+				failSpan(ctx, err)
 				return zero, err
 			}
 		}
@@ -208,13 +695,20 @@ func withRetry[T any](ctx context.Context, maxRetries int, baseBackoff time.Dura
 		case <-time.After(pause):
 		case <-ctx.Done():
 			logger.Error("withRetry: context canceled", zap.Int("attempts", i+1), zap.Duration("backoff", baseBackoff))
+			failSpan(ctx, ctx.Err())
 			return zero, ctx.Err()
 		}
 
 		logger.Warn("withRetry: retrying", zap.Int("attempt", i+1), zap.Duration("backoff", pause))
+		if onRetry != nil {
+			onRetry(i + 1)
+		}
 	}
 
-	return zero, fmt.Errorf("withRetry: all retries failed, lastErr: %w", lastErr)
+	err := fmt.Errorf("withRetry: all retries failed, lastErr: %w", lastErr)
+	failSpan(ctx, err)
+
+	return zero, err
 }
 
 func buildDSN(config *Config) string {
@@ -228,5 +722,45 @@ func buildDSN(config *Config) string {
 		config.MinConns,
 	)
 
+	for _, param := range sslParams(config) {
+		dsn += fmt.Sprintf(" %s=%s", param[0], param[1])
+	}
+
 	return dsn
 }
+
+// sslParams returns the TLS settings of the connection which are set in the config.
+func sslParams(config *Config) [][2]string {
+	var params [][2]string
+
+	for _, param := range [][2]string{
+		{"sslmode", config.SSLMode},
+		{"sslrootcert", config.SSLRootCert},
+		{"sslcert", config.SSLCert},
+		{"sslkey", config.SSLKey},
+	} {
+		if param[1] != "" {
+			params = append(params, param)
+		}
+	}
+
+	return params
+}
+
+// MigrationURL builds the connection url for the migrator, sslmode defaults to disable as before.
+func MigrationURL(config *Config) string {
+	query := url.Values{"sslmode": {"disable"}}
+	for _, param := range sslParams(config) {
+		query.Set(param[0], param[1])
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     net.JoinHostPort(config.Host, config.Port),
+		Path:     config.Database,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+func (s *Storage) CreateLink(ctx context.Context, link *Link) error {
+	ctx, span := startSpan(ctx, "CreateLink")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryCreateLink,
+			link.ID,
+			link.Kind,
+			link.Tenant,
+			link.FileID,
+			link.Name,
+			link.ContentType,
+			link.Owner,
+			link.Attachment,
+			link.MaxUses,
+			link.ExpiresAt,
+			link.CreatedAt,
+		)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("CreateLink: failed to insert link", zap.String("link_id", link.ID), zap.Error(err))
+		return fmt.Errorf("CreateLink: failed to insert link: %w", err)
+	}
+
+	s.logger.Info("CreateLink: successfully inserted link", zap.String("link_id", link.ID), zap.String("kind", link.Kind))
+	return nil
+}
+
+// UseLink counts a use of the link and returns it. A link that has expired, has been used up
+// or is of another kind is reported as missing.
+func (s *Storage) UseLink(ctx context.Context, id string, kind string, now time.Time) (*Link, error) {
+	ctx, span := startSpan(ctx, "UseLink")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	link, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*Link, error) {
+		return scanLink(s.pool.QueryRow(ctx, queryUseLink, id, kind, now))
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("UseLink: link not found", zap.String("link_id", id))
+			return nil, fmt.Errorf("UseLink: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("UseLink: failed to use link", zap.String("link_id", id), zap.Error(err))
+		return nil, fmt.Errorf("UseLink: failed to use link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (s *Storage) DeleteExpiredLinks(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "DeleteExpiredLinks")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryDeleteExpiredLinks, before)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("DeleteExpiredLinks: failed to delete links", zap.Error(err))
+		return 0, fmt.Errorf("DeleteExpiredLinks: failed to delete links: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func scanLink(row pgx.Row) (*Link, error) {
+	link := &Link{}
+
+	err := row.Scan(
+		&link.ID,
+		&link.Kind,
+		&link.Tenant,
+		&link.FileID,
+		&link.Name,
+		&link.ContentType,
+		&link.Owner,
+		&link.Attachment,
+		&link.MaxUses,
+		&link.Uses,
+		&link.ExpiresAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
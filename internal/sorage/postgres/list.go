@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildListFilesQuery appends the filters, the keyset condition and the ordering of the filter to queryListFilesInfo.
+func buildListFilesQuery(filter *ListFilter) (string, []any, error) {
+	column, err := sortColumn(filter.SortBy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var conditions []string
+	var args []any
+
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = "+arg(filter.Status))
+	} else if len(filter.HideStatuses) > 0 {
+		conditions = append(conditions, "status <> ALL("+arg(filter.HideStatuses)+")")
+	}
+	if !filter.NotExpiredAt.IsZero() {
+		conditions = append(conditions, "(expires_at IS NULL OR expires_at > "+arg(filter.NotExpiredAt)+")")
+	}
+	if filter.Tenant != "" {
+		conditions = append(conditions, "tenant = "+arg(filter.Tenant))
+	}
+	if filter.Accessor != nil {
+		conditions = append(conditions, accessCondition(filter.Accessor, arg))
+	}
+	if filter.NamePrefix != "" {
+		conditions = append(conditions, "name LIKE "+arg(escapeLike(filter.NamePrefix)+"%"))
+	}
+	if filter.NameContains != "" {
+		conditions = append(conditions, "name ILIKE "+arg("%"+escapeLike(filter.NameContains)+"%"))
+	}
+	if filter.ContentType != "" {
+		conditions = append(conditions, "content_type = "+arg(filter.ContentType))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(filter.CreatedBefore))
+	}
+	if filter.MinSize > 0 {
+		conditions = append(conditions, "size >= "+arg(filter.MinSize))
+	}
+	if filter.MaxSize > 0 {
+		conditions = append(conditions, "size <= "+arg(filter.MaxSize))
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.Cursor != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			column, comparison, arg(filter.Cursor.Value), arg(filter.Cursor.ID)))
+	}
+
+	var query strings.Builder
+	query.WriteString(queryListFilesInfo)
+
+	if len(conditions) > 0 {
+		query.WriteString(" WHERE ")
+		query.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	fmt.Fprintf(&query, " ORDER BY %s %s, id %s LIMIT %s", column, direction, direction, arg(filter.Limit))
+
+	if filter.Cursor == nil && filter.Offset > 0 {
+		query.WriteString(" OFFSET " + arg(filter.Offset))
+	}
+
+	return query.String(), args, nil
+}
+
+// accessCondition keeps the files without an owner, the files of the accessor and the ones it may read through the acl.
+func accessCondition(accessor *Accessor, arg func(any) string) string {
+	subject, groups := arg(accessor.Subject), arg(accessor.Groups)
+
+	return fmt.Sprintf(`(owner IS NULL OR owner = %[1]s OR EXISTS (SELECT 1 FROM schema_files.table_file_acl a 
+		WHERE a.file_id = schema_files.table_files.id AND a.permission = %[3]s 
+		AND ((a.grantee_type = %[4]s AND a.grantee = %[1]s) OR (a.grantee_type = %[5]s AND a.grantee = ANY(%[2]s)))))`,
+		subject, groups, arg(PermissionRead), arg(GranteeUser), arg(GranteeGroup))
+}
+
+// sortColumn maps the sort field to a column, so only known identifiers get into the query.
+func sortColumn(sortBy string) (string, error) {
+	switch sortBy {
+	case "", SortByCreatedAt:
+		return "created_at", nil
+	case SortByUpdatedAt:
+		return "updated_at", nil
+	case SortByName:
+		return "name", nil
+	case SortBySize:
+		return "size", nil
+	default:
+		return "", fmt.Errorf("sortColumn: unknown sort field: %s", sortBy)
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildListFilesQuery(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cursorID := "6f1c2c1e-8a43-4e43-9a8e-2f1d7b9a5c10"
+
+	tests := []struct {
+		name      string
+		filter    *ListFilter
+		wantWhere string
+		wantArgs  []any
+	}{
+		{
+			name:      "no filters",
+			filter:    &ListFilter{Limit: 10},
+			wantWhere: " ORDER BY created_at ASC, id ASC LIMIT $1",
+			wantArgs:  []any{int64(10)},
+		},
+		{
+			name:      "offset without cursor",
+			filter:    &ListFilter{Limit: 10, Offset: 20, Descending: true},
+			wantWhere: " ORDER BY created_at DESC, id DESC LIMIT $1 OFFSET $2",
+			wantArgs:  []any{int64(10), int64(20)},
+		},
+		{
+			name:      "status wins over hidden statuses",
+			filter:    &ListFilter{Limit: 5, Status: StatusTrashed, HideStatuses: []string{StatusPending}},
+			wantWhere: " WHERE status = $1 ORDER BY created_at ASC, id ASC LIMIT $2",
+			wantArgs:  []any{StatusTrashed, int64(5)},
+		},
+		{
+			name: "hidden statuses, expiry and tenant",
+			filter: &ListFilter{
+				Limit:        5,
+				HideStatuses: []string{StatusPending, StatusDeleting},
+				NotExpiredAt: now,
+				Tenant:       "acme",
+			},
+			wantWhere: " WHERE status <> ALL($1) AND (expires_at IS NULL OR expires_at > $2) AND tenant = $3" +
+				" ORDER BY created_at ASC, id ASC LIMIT $4",
+			wantArgs: []any{[]string{StatusPending, StatusDeleting}, now, "acme", int64(5)},
+		},
+		{
+			name: "name search escapes like wildcards",
+			filter: &ListFilter{
+				Limit:        5,
+				NamePrefix:   `50%_off\`,
+				NameContains: "a_b",
+				SortBy:       SortByName,
+			},
+			wantWhere: " WHERE name LIKE $1 AND name ILIKE $2 ORDER BY name ASC, id ASC LIMIT $3",
+			wantArgs:  []any{`50\%\_off\\%`, `%a\_b%`, int64(5)},
+		},
+		{
+			name: "content type, dates and sizes",
+			filter: &ListFilter{
+				Limit:         5,
+				ContentType:   "image/png",
+				CreatedAfter:  createdAfter,
+				CreatedBefore: createdBefore,
+				MinSize:       1,
+				MaxSize:       1024,
+				SortBy:        SortBySize,
+				Descending:    true,
+			},
+			wantWhere: " WHERE content_type = $1 AND created_at >= $2 AND created_at < $3 AND size >= $4 AND size <= $5" +
+				" ORDER BY size DESC, id DESC LIMIT $6",
+			wantArgs: []any{"image/png", createdAfter, createdBefore, int64(1), int64(1024), int64(5)},
+		},
+		{
+			name:      "ascending cursor ignores offset",
+			filter:    &ListFilter{Limit: 5, Offset: 10, SortBy: SortByUpdatedAt, Cursor: &Cursor{Value: now, ID: cursorID}},
+			wantWhere: " WHERE (updated_at, id) > ($1, $2) ORDER BY updated_at ASC, id ASC LIMIT $3",
+			wantArgs:  []any{now, cursorID, int64(5)},
+		},
+		{
+			name:      "descending cursor",
+			filter:    &ListFilter{Limit: 5, SortBy: SortByName, Descending: true, Cursor: &Cursor{Value: "b.txt", ID: cursorID}},
+			wantWhere: " WHERE (name, id) < ($1, $2) ORDER BY name DESC, id DESC LIMIT $3",
+			wantArgs:  []any{"b.txt", cursorID, int64(5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := buildListFilesQuery(tt.filter)
+			if err != nil {
+				t.Fatalf("buildListFilesQuery() error = %v", err)
+			}
+
+			where, ok := strings.CutPrefix(query, queryListFilesInfo)
+			if !ok {
+				t.Fatalf("query does not start with queryListFilesInfo: %s", query)
+			}
+
+			if where != tt.wantWhere {
+				t.Errorf("query =\n%s\nwant\n%s", where, tt.wantWhere)
+			}
+
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBuildListFilesQueryAccessor(t *testing.T) {
+	accessor := &Accessor{Subject: "alice", Groups: []string{"team"}}
+
+	query, args, err := buildListFilesQuery(&ListFilter{Limit: 5, Tenant: "acme", Accessor: accessor})
+	if err != nil {
+		t.Fatalf("buildListFilesQuery() error = %v", err)
+	}
+
+	if !strings.Contains(query, "tenant = $1 AND (owner IS NULL OR owner = $2 OR EXISTS") {
+		t.Errorf("query does not restrict the files to the accessor: %s", query)
+	}
+
+	if !strings.HasSuffix(query, " LIMIT $7") {
+		t.Errorf("query does not end with the limit: %s", query)
+	}
+
+	wantArgs := []any{"acme", "alice", []string{"team"}, PermissionRead, GranteeUser, GranteeGroup, int64(5)}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %#v, want %#v", args, wantArgs)
+	}
+}
+
+func TestBuildListFilesQueryUnknownSort(t *testing.T) {
+	_, _, err := buildListFilesQuery(&ListFilter{Limit: 5, SortBy: "owner; DROP TABLE x"})
+	if err == nil {
+		t.Fatal("buildListFilesQuery() error = nil, want error for an unknown sort field")
+	}
+}
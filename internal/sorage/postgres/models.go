@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	fileservice "github.com/ladev74/protos/gen/go/file_service"
 	"go.uber.org/zap"
 )
 
@@ -18,6 +19,11 @@ type Config struct {
 	BaseBackoff time.Duration `yaml:"base_backoff" env-required:"true"`
 	MaxConns    int           `yaml:"max_connections" env-required:"true"`
 	MinConns    int           `yaml:"min_connections" env-required:"true"`
+	// SSLMode and the files below follow the libpq sslmode, sslrootcert, sslcert and sslkey parameters.
+	SSLMode     string `yaml:"ssl_mode"`
+	SSLRootCert string `yaml:"ssl_root_cert"`
+	SSLCert     string `yaml:"ssl_cert"`
+	SSLKey      string `yaml:"ssl_key"`
 }
 
 type Storage struct {
@@ -26,4 +32,188 @@ type Storage struct {
 	timeout     time.Duration
 	maxRetries  int
 	baseBackoff time.Duration
+	// OnRetry is called before every retry of a failed call, it has to be set before the storage is used.
+	OnRetry func(attempt int)
+}
+
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByName      = "name"
+	SortBySize      = "size"
+)
+
+// Cursor points at the last file of a page, the next page starts right after it.
+// Value holds the sort column of that file: time.Time for dates, string for name and int64 for size.
+type Cursor struct {
+	Value any
+	ID    string
+}
+
+// ListFilter describes a page of files. Zero values of the filters mean no filtering.
+type ListFilter struct {
+	Limit        int64
+	Offset       int64
+	Cursor       *Cursor
+	Status       string
+	HideStatuses []string
+	// NotExpiredAt hides files which have expired by that time.
+	NotExpiredAt time.Time
+	// Tenant limits the list to the files of the tenant, empty lists the files of every tenant.
+	Tenant string
+	// Accessor limits the list to the files it may read, nil lists every file.
+	Accessor      *Accessor
+	NamePrefix    string
+	NameContains  string
+	ContentType   string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	MinSize       int64
+	MaxSize       int64
+	SortBy        string
+	Descending    bool
+}
+
+// File is the stored metadata of a file together with the location of its content.
+type File struct {
+	Info      *fileservice.FileInfo
+	ObjectKey string
+	// BlobHash is set when the content is a deduplicated blob shared with other files.
+	BlobHash string
+	Tenant   string
+}
+
+// PendingFile is a file being uploaded, it is saved before its content.
+type PendingFile struct {
+	ID          string
+	Tenant      string
+	ObjectKey   string
+	Name        string
+	ContentType string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// ExpiresAt nil keeps the file until it is deleted.
+	ExpiresAt *time.Time
+	// Owner empty leaves the file accessible to everyone.
+	Owner string
+}
+
+const (
+	SessionStatusActive    = "active"
+	SessionStatusCompleted = "completed"
+)
+
+// OwnObjectKeys returns the objects which belong to the file alone, shared blobs are not included.
+func (f *File) OwnObjectKeys(versions []*FileVersion) []string {
+	var keys []string
+	seen := make(map[string]struct{})
+
+	add := func(key string, blobHash string) {
+		if _, ok := seen[key]; ok || blobHash != "" {
+			return
+		}
+
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	add(f.ObjectKey, f.BlobHash)
+	for _, version := range versions {
+		add(version.ObjectKey, version.BlobHash)
+	}
+
+	return keys
+}
+
+// FileVersion is a stored content of a file. The current version is mirrored in table_files.
+type FileVersion struct {
+	Number      int32
+	ObjectKey   string
+	BlobHash    string
+	Size        int64
+	ContentType string
+	Checksum    string
+	CRC32C      string
+	CreatedAt   time.Time
+}
+
+type UploadSession struct {
+	ID          string
+	FileID      string
+	UploadID    string
+	Name        string
+	ContentType string
+	Status      string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	Owner       string
+	Tenant      string
+}
+
+const (
+	GranteeUser  = "user"
+	GranteeGroup = "group"
+)
+
+const (
+	PermissionRead   = "read"
+	PermissionWrite  = "write"
+	PermissionDelete = "delete"
+)
+
+// Accessor is the caller whose access to files is checked against the owner and the acl.
+type Accessor struct {
+	Subject string
+	Groups  []string
+}
+
+// AccessEntry grants one permission on a file to a user or to a group.
+type AccessEntry struct {
+	GranteeType string
+	Grantee     string
+	Permission  string
+	CreatedAt   time.Time
+}
+
+type Tenant struct {
+	ID        string
+	Name      string
+	Disabled  bool
+	CreatedAt time.Time
+}
+
+// Usage is what a tenant stores: the size of all file versions and the number of files.
+type Usage struct {
+	Bytes int64
+	Files int64
+}
+
+const (
+	LinkDownload = "download"
+	LinkUpload   = "upload"
+)
+
+// Link lets the bearer of its token download a file or upload a new one without credentials.
+type Link struct {
+	ID     string
+	Kind   string
+	Tenant string
+	// FileID is the file to download, upload links create a new file on every use.
+	FileID      string
+	Name        string
+	ContentType string
+	Owner       string
+	Attachment  bool
+	// MaxUses zero allows any number of uses until the link expires.
+	MaxUses   int32
+	Uses      int32
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type UploadPart struct {
+	Number    int32
+	ETag      string
+	Size      int64
+	CreatedAt time.Time
 }
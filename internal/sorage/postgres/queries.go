@@ -1,14 +1,158 @@
 package postgres
 
 const (
-	querySaveFileInfo = `INSERT INTO schema_files.table_files (id, name, created_at, updated_at, status) VALUES ($1, $2, $3, $4, $5)`
+	querySaveFileInfo = `INSERT INTO schema_files.table_files 
+						(id, name, content_type, created_at, updated_at, status, object_key, expires_at, owner, tenant) 
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, nullif($9, ''), $10)`
 
-	querySetSuccessStatus = `UPDATE schema_files.table_files SET status = $1 WHERE id = $2`
+	querySetSuccessStatus = `UPDATE schema_files.table_files SET status = $1, size = $2, checksum = $3, crc32c = $4, version = 1 
+						WHERE id = $5`
 
-	queryDeleteFileInfo = `DELETE FROM schema_files.table_files WHERE id = $1`
+	queryInsertFirstVersion = `INSERT INTO schema_files.table_file_versions 
+						(file_id, version, object_key, blob_hash, size, content_type, checksum, crc32c, created_at) 
+						SELECT id, version, object_key, blob_hash, size, content_type, checksum, crc32c, updated_at 
+						FROM schema_files.table_files WHERE id = $1 
+						ON CONFLICT (file_id, version) DO NOTHING`
 
-	queryListFilesInfo = `SELECT name, created_at, updated_at 
-						FROM schema_files.table_files ORDER BY created_at DESC LIMIT $1	OFFSET $2`
+	querySetChecksums = `UPDATE schema_files.table_files SET checksum = $1, crc32c = $2 WHERE id = $3`
 
-	queryGetFIleName = `SELECT name FROM schema_files.table_files WHERE id = $1`
+	querySetVersionChecksums = `UPDATE schema_files.table_file_versions v SET checksum = $1, crc32c = $2 
+						FROM schema_files.table_files f WHERE f.id = $3 AND v.file_id = f.id AND v.version = f.version`
+
+	queryDeleteFileVersions = `DELETE FROM schema_files.table_file_versions WHERE file_id = $1 RETURNING coalesce(blob_hash, ''), size`
+
+	queryDeleteFileInfo = `DELETE FROM schema_files.table_files WHERE id = $1 RETURNING coalesce(blob_hash, ''), tenant`
+
+	queryListFilesInfo = `SELECT id, name, status, size, content_type, version, created_at, updated_at, deleted_at, expires_at, 
+						coalesce(owner, '') FROM schema_files.table_files`
+
+	queryGetFileInfo = `SELECT id, name, content_type, size, checksum, crc32c, status, version, created_at, updated_at, 
+						deleted_at, expires_at, object_key, coalesce(blob_hash, ''), coalesce(owner, ''), tenant 
+						FROM schema_files.table_files WHERE id = $1`
+
+	queryListStaleFiles = `SELECT id, name, content_type, size, checksum, crc32c, status, version, created_at, updated_at, 
+						deleted_at, expires_at, object_key, coalesce(blob_hash, ''), coalesce(owner, ''), tenant 
+						FROM schema_files.table_files WHERE status = $1 AND updated_at < $2 ORDER BY updated_at LIMIT $3`
+
+	queryListTrashedFiles = `SELECT id, name, content_type, size, checksum, crc32c, status, version, created_at, updated_at, 
+						deleted_at, expires_at, object_key, coalesce(blob_hash, ''), coalesce(owner, ''), tenant 
+						FROM schema_files.table_files WHERE status = $1 AND deleted_at < $2 ORDER BY deleted_at LIMIT $3`
+
+	queryListExpiredFiles = `SELECT id, name, content_type, size, checksum, crc32c, status, version, created_at, updated_at, 
+						deleted_at, expires_at, object_key, coalesce(blob_hash, ''), coalesce(owner, ''), tenant 
+						FROM schema_files.table_files WHERE status = ANY($1) AND expires_at < $2 ORDER BY expires_at LIMIT $3`
+
+	queryTrashFile = `UPDATE schema_files.table_files SET status = $1, deleted_at = $2 WHERE id = $3 AND status = $4`
+
+	queryRestoreFile = `UPDATE schema_files.table_files SET status = $1, deleted_at = NULL WHERE id = $2 AND status = $3`
+
+	queryExistingObjectKeys = `SELECT object_key FROM schema_files.table_files WHERE object_key = ANY($1) 
+						UNION SELECT object_key FROM schema_files.table_file_versions WHERE object_key = ANY($1)`
+
+	queryTouchPendingFile = `UPDATE schema_files.table_files SET updated_at = $1 WHERE id = $2 AND status = $3`
+
+	queryMarkDeleting = `UPDATE schema_files.table_files SET status = $1, updated_at = $2 
+						WHERE id = $3 AND (status = $1 OR status = ANY($4))`
+
+	querySaveUploadSession = `INSERT INTO schema_files.table_upload_sessions 
+						(id, file_id, upload_id, name, content_type, status, created_at, expires_at, owner, tenant) 
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, nullif($9, ''), $10)`
+
+	queryGetUploadSession = `SELECT id, file_id, upload_id, name, content_type, status, created_at, expires_at, coalesce(owner, ''), tenant 
+						FROM schema_files.table_upload_sessions WHERE id = $1`
+
+	querySetUploadSessionStatus = `UPDATE schema_files.table_upload_sessions SET status = $1 WHERE id = $2`
+
+	queryDeleteUploadSession = `DELETE FROM schema_files.table_upload_sessions WHERE id = $1`
+
+	queryListExpiredUploadSessions = `SELECT id, file_id, upload_id, name, content_type, status, created_at, expires_at, coalesce(owner, ''), tenant 
+						FROM schema_files.table_upload_sessions WHERE expires_at < $1 ORDER BY expires_at LIMIT $2`
+
+	querySaveUploadPart = `INSERT INTO schema_files.table_upload_parts (session_id, part_number, etag, size, created_at) 
+						VALUES ($1, $2, $3, $4, $5) 
+						ON CONFLICT (session_id, part_number) DO UPDATE SET etag = $3, size = $4, created_at = $5`
+
+	queryListUploadParts = `SELECT part_number, etag, size, created_at 
+						FROM schema_files.table_upload_parts WHERE session_id = $1 ORDER BY part_number`
+
+	queryAcquireBlob = `INSERT INTO schema_files.table_blobs (hash, size, ref_count, created_at) VALUES ($1, $2, 1, $3) 
+						ON CONFLICT (hash) DO UPDATE SET ref_count = schema_files.table_blobs.ref_count + 1 
+						RETURNING ref_count`
+
+	queryReleaseBlob = `UPDATE schema_files.table_blobs SET ref_count = ref_count - 1 WHERE hash = $1 RETURNING ref_count`
+
+	queryDeleteUnusedBlob = `DELETE FROM schema_files.table_blobs WHERE hash = $1 AND ref_count <= 0`
+
+	queryAttachBlob = `UPDATE schema_files.table_files SET object_key = $1, blob_hash = $2 WHERE id = $3 AND object_key = $4`
+
+	queryAttachVersionBlob = `UPDATE schema_files.table_file_versions SET object_key = $1, blob_hash = $2 
+						WHERE file_id = $3 AND object_key = $4`
+
+	queryLockFile = `SELECT status FROM schema_files.table_files WHERE id = $1 FOR UPDATE`
+
+	queryNextFileVersion = `SELECT coalesce(max(version), 0) + 1 FROM schema_files.table_file_versions WHERE file_id = $1`
+
+	queryInsertFileVersion = `INSERT INTO schema_files.table_file_versions 
+						(file_id, version, object_key, blob_hash, size, content_type, checksum, crc32c, created_at) 
+						VALUES ($1, $2, $3, nullif($4, ''), $5, $6, $7, $8, $9)`
+
+	querySetCurrentVersion = `UPDATE schema_files.table_files f SET object_key = v.object_key, blob_hash = v.blob_hash, 
+						size = v.size, content_type = v.content_type, checksum = v.checksum, crc32c = v.crc32c, 
+						version = v.version, updated_at = $3 
+						FROM schema_files.table_file_versions v 
+						WHERE f.id = $1 AND v.file_id = f.id AND v.version = $2 AND f.status = $4`
+
+	queryListFileVersions = `SELECT version, object_key, coalesce(blob_hash, ''), size, content_type, checksum, crc32c, created_at 
+						FROM schema_files.table_file_versions WHERE file_id = $1 ORDER BY version`
+
+	queryGetFileVersion = `SELECT version, object_key, coalesce(blob_hash, ''), size, content_type, checksum, crc32c, created_at 
+						FROM schema_files.table_file_versions WHERE file_id = $1 AND version = $2`
+
+	queryGrantAccess = `INSERT INTO schema_files.table_file_acl (file_id, grantee_type, grantee, permission, created_at) 
+						SELECT $1, $2, $3, unnest($4::text[]), $5 
+						ON CONFLICT (file_id, grantee_type, grantee, permission) DO NOTHING`
+
+	queryRevokeAccess = `DELETE FROM schema_files.table_file_acl 
+						WHERE file_id = $1 AND grantee_type = $2 AND grantee = $3 AND (cardinality($4::text[]) = 0 OR permission = ANY($4))`
+
+	queryListAccess = `SELECT grantee_type, grantee, permission, created_at 
+						FROM schema_files.table_file_acl WHERE file_id = $1 ORDER BY grantee_type, grantee, permission`
+
+	queryHasPermission = `SELECT EXISTS (SELECT 1 FROM schema_files.table_file_acl 
+						WHERE file_id = $1 AND permission = $2 
+						AND ((grantee_type = $3 AND grantee = $4) OR (grantee_type = $5 AND grantee = ANY($6))))`
+
+	queryCreateTenant = `INSERT INTO schema_files.table_tenants (id, name, disabled, created_at) VALUES ($1, $2, false, $3)`
+
+	queryGetTenant = `SELECT id, name, disabled, created_at FROM schema_files.table_tenants WHERE id = $1`
+
+	queryListTenants = `SELECT id, name, disabled, created_at FROM schema_files.table_tenants ORDER BY id`
+
+	queryDisableTenant = `UPDATE schema_files.table_tenants SET disabled = true WHERE id = $1`
+
+	// queryReserveFile counts a new file of the tenant unless the file limit is reached, a zero limit means no limit.
+	queryReserveFile = `INSERT INTO schema_files.table_usage AS u (tenant, bytes, files) VALUES ($1, 0, 1) 
+						ON CONFLICT (tenant) DO UPDATE SET files = u.files + 1 
+						WHERE $2::bigint = 0 OR u.files < $2 
+						RETURNING u.files`
+
+	queryAddUsageBytes = `UPDATE schema_files.table_usage u SET bytes = u.bytes + $2 
+						FROM schema_files.table_files f 
+						WHERE f.id = $1 AND u.tenant = f.tenant AND ($3::bigint = 0 OR u.bytes + $2 <= $3)`
+
+	queryReleaseUsage = `UPDATE schema_files.table_usage SET files = files - 1, bytes = bytes - $2 WHERE tenant = $1`
+
+	queryGetUsage = `SELECT bytes, files FROM schema_files.table_usage WHERE tenant = $1`
+
+	queryCreateLink = `INSERT INTO schema_files.table_links 
+						(id, kind, tenant, file_id, name, content_type, owner, attachment, max_uses, uses, expires_at, created_at) 
+						VALUES ($1, $2, $3, nullif($4, '')::uuid, $5, $6, nullif($7, ''), $8, $9, 0, $10, $11)`
+
+	// queryUseLink counts a use of the link unless it has expired or has been used up.
+	queryUseLink = `UPDATE schema_files.table_links SET uses = uses + 1 
+						WHERE id = $1 AND kind = $2 AND expires_at > $3 AND (max_uses = 0 OR uses < max_uses) 
+						RETURNING id, kind, tenant, coalesce(file_id::text, ''), name, content_type, coalesce(owner, ''), 
+						attachment, max_uses, uses, expires_at, created_at`
+
+	queryDeleteExpiredLinks = `DELETE FROM schema_files.table_links WHERE expires_at <= $1`
 )
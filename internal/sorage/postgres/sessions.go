@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+func (s *Storage) SaveUploadSession(ctx context.Context, session *UploadSession) error {
+	ctx, span := startSpan(ctx, "SaveUploadSession")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, querySaveUploadSession,
+			session.ID,
+			session.FileID,
+			session.UploadID,
+			session.Name,
+			session.ContentType,
+			session.Status,
+			session.CreatedAt,
+			session.ExpiresAt,
+			session.Owner,
+			session.Tenant,
+		)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("SaveUploadSession: failed to insert upload session", zap.Error(err))
+		return fmt.Errorf("SaveUploadSession: failed to insert upload session: %w", err)
+	}
+
+	s.logger.Info("SaveUploadSession: successfully inserted upload session", zap.String("session_id", session.ID))
+	return nil
+}
+
+func (s *Storage) GetUploadSession(ctx context.Context, id string) (*UploadSession, error) {
+	ctx, span := startSpan(ctx, "GetUploadSession")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	session, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*UploadSession, error) {
+		return scanUploadSession(s.pool.QueryRow(ctx, queryGetUploadSession, id))
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("GetUploadSession: upload session not found", zap.String("session_id", id))
+			return nil, fmt.Errorf("GetUploadSession: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("GetUploadSession: failed to get upload session", zap.String("session_id", id), zap.Error(err))
+		return nil, fmt.Errorf("GetUploadSession: failed to get upload session: %w", err)
+	}
+
+	s.logger.Info("GetUploadSession: successfully retrieved upload session", zap.String("session_id", id))
+	return session, nil
+}
+
+func (s *Storage) SetUploadSessionStatus(ctx context.Context, id string, status string) error {
+	ctx, span := startSpan(ctx, "SetUploadSessionStatus")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, querySetUploadSessionStatus, status, id)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("SetUploadSessionStatus: failed to set status", zap.String("session_id", id), zap.Error(err))
+		return fmt.Errorf("SetUploadSessionStatus: failed to set status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("SetUploadSessionStatus: upload session not found", zap.String("session_id", id))
+		return fmt.Errorf("SetUploadSessionStatus: %w: %s", ErrNotFound, id)
+	}
+
+	s.logger.Info("SetUploadSessionStatus: successfully set status",
+		zap.String("session_id", id),
+		zap.String("status", status),
+	)
+	return nil
+}
+
+func (s *Storage) DeleteUploadSession(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "DeleteUploadSession")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryDeleteUploadSession, id)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("DeleteUploadSession: failed to delete upload session", zap.String("session_id", id), zap.Error(err))
+		return fmt.Errorf("DeleteUploadSession: failed to delete upload session: %w", err)
+	}
+
+	s.logger.Info("DeleteUploadSession: successfully deleted upload session", zap.String("session_id", id))
+	return nil
+}
+
+func (s *Storage) ListExpiredUploadSessions(ctx context.Context, now time.Time, limit int) ([]*UploadSession, error) {
+	ctx, span := startSpan(ctx, "ListExpiredUploadSessions")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, queryListExpiredUploadSessions, now, limit)
+		return rows, err
+	})
+	if err != nil {
+		s.logger.Error("ListExpiredUploadSessions: failed to get upload sessions", zap.Error(err))
+		return nil, fmt.Errorf("ListExpiredUploadSessions: failed to get upload sessions: %w", err)
+	}
+
+	defer rows.Close()
+	sessions := make([]*UploadSession, 0, limit)
+
+	for rows.Next() {
+		session, err := scanUploadSession(rows)
+		if err != nil {
+			s.logger.Error("ListExpiredUploadSessions: failed to scan upload sessions", zap.Error(err))
+			return nil, fmt.Errorf("ListExpiredUploadSessions: failed to scan upload sessions: %w", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		s.logger.Error("ListExpiredUploadSessions: failed to scan upload sessions", zap.Error(err))
+		return nil, fmt.Errorf("ListExpiredUploadSessions: failed to scan upload sessions: %w", err)
+	}
+
+	s.logger.Info("ListExpiredUploadSessions: successfully retrieved upload sessions", zap.Int("count", len(sessions)))
+	return sessions, nil
+}
+
+func (s *Storage) SaveUploadPart(ctx context.Context, sessionID string, part *UploadPart) error {
+	ctx, span := startSpan(ctx, "SaveUploadPart")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, querySaveUploadPart, sessionID, part.Number, part.ETag, part.Size, part.CreatedAt)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("SaveUploadPart: failed to insert upload part",
+			zap.String("session_id", sessionID),
+			zap.Int32("part_number", part.Number),
+			zap.Error(err),
+		)
+		return fmt.Errorf("SaveUploadPart: failed to insert upload part: %w", err)
+	}
+
+	s.logger.Info("SaveUploadPart: successfully inserted upload part",
+		zap.String("session_id", sessionID),
+		zap.Int32("part_number", part.Number),
+	)
+	return nil
+}
+
+func (s *Storage) ListUploadParts(ctx context.Context, sessionID string) ([]*UploadPart, error) {
+	ctx, span := startSpan(ctx, "ListUploadParts")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, queryListUploadParts, sessionID)
+		return rows, err
+	})
+	if err != nil {
+		s.logger.Error("ListUploadParts: failed to get upload parts", zap.String("session_id", sessionID), zap.Error(err))
+		return nil, fmt.Errorf("ListUploadParts: failed to get upload parts: %w", err)
+	}
+
+	defer rows.Close()
+	var parts []*UploadPart
+
+	for rows.Next() {
+		part := &UploadPart{}
+
+		err = rows.Scan(&part.Number, &part.ETag, &part.Size, &part.CreatedAt)
+		if err != nil {
+			s.logger.Error("ListUploadParts: failed to scan upload parts", zap.Error(err))
+			return nil, fmt.Errorf("ListUploadParts: failed to scan upload parts: %w", err)
+		}
+
+		parts = append(parts, part)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		s.logger.Error("ListUploadParts: failed to scan upload parts", zap.Error(err))
+		return nil, fmt.Errorf("ListUploadParts: failed to scan upload parts: %w", err)
+	}
+
+	s.logger.Info("ListUploadParts: successfully retrieved upload parts",
+		zap.String("session_id", sessionID),
+		zap.Int("count", len(parts)),
+	)
+	return parts, nil
+}
+
+func scanUploadSession(row pgx.Row) (*UploadSession, error) {
+	session := &UploadSession{}
+
+	err := row.Scan(
+		&session.ID,
+		&session.FileID,
+		&session.UploadID,
+		&session.Name,
+		&session.ContentType,
+		&session.Status,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+		&session.Owner,
+		&session.Tenant,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// uniqueViolation is the SQLSTATE of a duplicate primary key.
+const uniqueViolation = "23505"
+
+// invalidTextRepresentation is the SQLSTATE of a value that cannot be cast to the column type, such as a malformed uuid.
+const invalidTextRepresentation = "22P02"
+
+func (s *Storage) CreateTenant(ctx context.Context, tenant *Tenant) error {
+	ctx, span := startSpan(ctx, "CreateTenant")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryCreateTenant, tenant.ID, tenant.Name, tenant.CreatedAt)
+		return tag, err
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			s.logger.Warn("CreateTenant: tenant already exists", zap.String("tenant", tenant.ID))
+			return fmt.Errorf("CreateTenant: %w: %s", ErrAlreadyExists, tenant.ID)
+		}
+
+		s.logger.Error("CreateTenant: failed to insert tenant", zap.String("tenant", tenant.ID), zap.Error(err))
+		return fmt.Errorf("CreateTenant: failed to insert tenant: %w", err)
+	}
+
+	s.logger.Info("CreateTenant: successfully inserted tenant", zap.String("tenant", tenant.ID))
+	return nil
+}
+
+func (s *Storage) GetTenant(ctx context.Context, id string) (*Tenant, error) {
+	ctx, span := startSpan(ctx, "GetTenant")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tenant, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*Tenant, error) {
+		return scanTenant(s.pool.QueryRow(ctx, queryGetTenant, id))
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("GetTenant: tenant not found", zap.String("tenant", id))
+			return nil, fmt.Errorf("GetTenant: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("GetTenant: failed to get tenant", zap.String("tenant", id), zap.Error(err))
+		return nil, fmt.Errorf("GetTenant: failed to get tenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+func (s *Storage) ListTenants(ctx context.Context) ([]*Tenant, error) {
+	ctx, span := startSpan(ctx, "ListTenants")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, queryListTenants)
+		return rows, err
+	})
+	if err != nil {
+		s.logger.Error("ListTenants: failed to get tenants", zap.Error(err))
+		return nil, fmt.Errorf("ListTenants: failed to get tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*Tenant
+
+	for rows.Next() {
+		tenant, err := scanTenant(rows)
+		if err != nil {
+			s.logger.Error("ListTenants: failed to scan tenants", zap.Error(err))
+			return nil, fmt.Errorf("ListTenants: failed to scan tenants: %w", err)
+		}
+
+		tenants = append(tenants, tenant)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		s.logger.Error("ListTenants: failed to scan tenants", zap.Error(err))
+		return nil, fmt.Errorf("ListTenants: failed to scan tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// DisableTenant rejects further calls of the tenant, its files are kept.
+func (s *Storage) DisableTenant(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "DisableTenant")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, queryDisableTenant, id)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("DisableTenant: failed to disable tenant", zap.String("tenant", id), zap.Error(err))
+		return fmt.Errorf("DisableTenant: failed to disable tenant: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("DisableTenant: tenant not found", zap.String("tenant", id))
+		return fmt.Errorf("DisableTenant: %w: %s", ErrNotFound, id)
+	}
+
+	s.logger.Info("DisableTenant: successfully disabled tenant", zap.String("tenant", id))
+	return nil
+}
+
+func scanTenant(row pgx.Row) (*Tenant, error) {
+	tenant := &Tenant{}
+
+	err := row.Scan(&tenant.ID, &tenant.Name, &tenant.Disabled, &tenant.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("fileservice/internal/sorage/postgres")
+
+// startSpan starts the span of a storage call, the attempts made by withRetry become its children.
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "postgres."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("storage", "postgres")),
+	)
+}
+
+func startAttempt(ctx context.Context, attempt int) trace.Span {
+	_, span := tracer.Start(ctx, "withRetry", trace.WithAttributes(attribute.Int("attempt", attempt)))
+	return span
+}
+
+func endAttempt(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+// failSpan marks the span of the storage call as failed once withRetry gives up.
+func failSpan(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// GetUsage returns the bytes and the files stored by the tenant, a tenant without files has zero usage.
+func (s *Storage) GetUsage(ctx context.Context, tenant string) (*Usage, error) {
+	ctx, span := startSpan(ctx, "GetUsage")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	usage, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*Usage, error) {
+		usage := &Usage{}
+		err := s.pool.QueryRow(ctx, queryGetUsage, tenant).Scan(&usage.Bytes, &usage.Files)
+		return usage, err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &Usage{}, nil
+		}
+
+		s.logger.Error("GetUsage: failed to get usage", zap.String("tenant", tenant), zap.Error(err))
+		return nil, fmt.Errorf("GetUsage: failed to get usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// addUsageBytes adds the size to the usage of the tenant of the file unless it exceeds maxBytes.
+func addUsageBytes(ctx context.Context, tx pgx.Tx, id string, size int64, maxBytes int64) error {
+	tag, err := tx.Exec(ctx, queryAddUsageBytes, id, size, maxBytes)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrQuotaExceeded
+	}
+
+	return nil
+}
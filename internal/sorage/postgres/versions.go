@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// AddFileVersion stores a new version of an uploaded file and makes it current. The number of the version is returned.
+// The size of the version is added to the usage of the tenant, ErrQuotaExceeded is returned when it does not fit into maxBytes.
+func (s *Storage) AddFileVersion(ctx context.Context, id string, version *FileVersion, maxBytes int64) (int32, error) {
+	ctx, span := startSpan(ctx, "AddFileVersion")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	number, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (int32, error) {
+		var number int32
+
+		err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+			// The row lock serializes concurrent uploads of the same file, so version numbers do not clash.
+			var status string
+
+			err := tx.QueryRow(ctx, queryLockFile, id).Scan(&status)
+			if err != nil {
+				return err
+			}
+			if status != StatusSuccess {
+				return pgx.ErrNoRows
+			}
+
+			err = tx.QueryRow(ctx, queryNextFileVersion, id).Scan(&number)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, queryInsertFileVersion,
+				id,
+				number,
+				version.ObjectKey,
+				version.BlobHash,
+				version.Size,
+				version.ContentType,
+				version.Checksum,
+				version.CRC32C,
+				version.CreatedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, querySetCurrentVersion, id, number, version.CreatedAt, StatusSuccess)
+			if err != nil {
+				return err
+			}
+
+			return addUsageBytes(ctx, tx, id, version.Size, maxBytes)
+		})
+
+		return number, err
+	})
+	if err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			s.logger.Warn("AddFileVersion: byte quota exceeded", zap.String("id", id), zap.Int64("max_bytes", maxBytes))
+			return 0, fmt.Errorf("AddFileVersion: %w: bytes", ErrQuotaExceeded)
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("AddFileVersion: file not found", zap.String("id", id))
+			return 0, fmt.Errorf("AddFileVersion: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("AddFileVersion: failed to add file version", zap.String("id", id), zap.Error(err))
+		return 0, fmt.Errorf("AddFileVersion: failed to add file version: %w", err)
+	}
+
+	s.logger.Info("AddFileVersion: successfully added file version", zap.String("id", id), zap.Int32("version", number))
+	return number, nil
+}
+
+// SetCurrentVersion rolls an uploaded file back to one of its versions.
+func (s *Storage) SetCurrentVersion(ctx context.Context, id string, version int32) error {
+	ctx, span := startSpan(ctx, "SetCurrentVersion")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	tag, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgconn.CommandTag, error) {
+		tag, err := s.pool.Exec(ctx, querySetCurrentVersion, id, version, time.Now().UTC(), StatusSuccess)
+		return tag, err
+	})
+	if err != nil {
+		s.logger.Error("SetCurrentVersion: failed to set current version", zap.String("id", id), zap.Error(err))
+		return fmt.Errorf("SetCurrentVersion: failed to set current version: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.logger.Warn("SetCurrentVersion: file version not found", zap.String("id", id), zap.Int32("version", version))
+		return fmt.Errorf("SetCurrentVersion: %w: %s", ErrNotFound, id)
+	}
+
+	s.logger.Info("SetCurrentVersion: successfully set current version", zap.String("id", id), zap.Int32("version", version))
+	return nil
+}
+
+func (s *Storage) ListFileVersions(ctx context.Context, id string) ([]*FileVersion, error) {
+	ctx, span := startSpan(ctx, "ListFileVersions")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rows, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (pgx.Rows, error) {
+		rows, err := s.pool.Query(ctx, queryListFileVersions, id)
+		return rows, err
+	})
+	if err != nil {
+		s.logger.Error("ListFileVersions: failed to get file versions", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("ListFileVersions: failed to get file versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*FileVersion
+
+	for rows.Next() {
+		version, err := scanFileVersion(rows)
+		if err != nil {
+			s.logger.Error("ListFileVersions: failed to scan file versions", zap.String("id", id), zap.Error(err))
+			return nil, fmt.Errorf("ListFileVersions: failed to scan file versions: %w", err)
+		}
+
+		versions = append(versions, version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		s.logger.Error("ListFileVersions: failed to scan file versions", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("ListFileVersions: failed to scan file versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (s *Storage) GetFileVersion(ctx context.Context, id string, version int32) (*FileVersion, error) {
+	ctx, span := startSpan(ctx, "GetFileVersion")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	fileVersion, err := withRetry(ctx, s.maxRetries, s.baseBackoff, s.logger, s.OnRetry, func() (*FileVersion, error) {
+		return scanFileVersion(s.pool.QueryRow(ctx, queryGetFileVersion, id, version))
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("GetFileVersion: file version not found", zap.String("id", id), zap.Int32("version", version))
+			return nil, fmt.Errorf("GetFileVersion: %w: %s", ErrNotFound, id)
+		}
+
+		s.logger.Error("GetFileVersion: failed to get file version", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("GetFileVersion: failed to get file version: %w", err)
+	}
+
+	return fileVersion, nil
+}
+
+func scanFileVersion(row pgx.Row) (*FileVersion, error) {
+	version := &FileVersion{}
+
+	err := row.Scan(
+		&version.Number,
+		&version.ObjectKey,
+		&version.BlobHash,
+		&version.Size,
+		&version.ContentType,
+		&version.Checksum,
+		&version.CRC32C,
+		&version.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
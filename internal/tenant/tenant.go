@@ -0,0 +1,56 @@
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"fileservice/internal/sorage/minio"
+)
+
+// Default owns the files uploaded before tenants were introduced and the calls which do not name a tenant.
+const Default = "default"
+
+// MetadataKey is the request metadata which selects the tenant when the credentials are not bound to one.
+const MetadataKey = "x-tenant-id"
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// ValidID reports whether the id is safe to be used in object keys.
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+type tenantKey struct{}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the tenant of the call, Default when none was resolved.
+func FromContext(ctx context.Context) string {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	if !ok || id == "" {
+		return Default
+	}
+
+	return id
+}
+
+// ObjectKey places the object under the prefix of the tenant. The default tenant keeps plain keys,
+// so objects uploaded before tenants stay where they are.
+func ObjectKey(tenant string, key string) string {
+	if tenant == "" || tenant == Default {
+		return key
+	}
+
+	return minio.TenantKey(tenant, key)
+}
+
+// BlobHash scopes a content hash to the tenant, so deduplication never shares objects between tenants.
+func BlobHash(tenant string, sum string) string {
+	if tenant == "" || tenant == Default {
+		return sum
+	}
+
+	return tenant + "/" + sum
+}
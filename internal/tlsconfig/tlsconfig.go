@@ -0,0 +1,187 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	Enabled  bool   `yaml:"enabled" env-default:"false"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile enables client certificates, they are verified against these CAs.
+	ClientCAFile      string        `yaml:"client_ca_file"`
+	RequireClientCert bool          `yaml:"require_client_cert" env-default:"false"`
+	ReloadInterval    time.Duration `yaml:"reload_interval" env-default:"1m"`
+}
+
+// Reloader keeps the server certificate and the client CAs up to date with the files on disk,
+// so rotated certificates are picked up without a restart.
+type Reloader struct {
+	config    *Config
+	logger    *zap.Logger
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func NewReloader(config *Config, logger *zap.Logger) (*Reloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("NewReloader: cert_file and key_file are required")
+	}
+
+	if config.RequireClientCert && config.ClientCAFile == "" {
+		return nil, errors.New("NewReloader: client_ca_file is required to verify client certificates")
+	}
+
+	r := &Reloader{
+		config: config,
+		logger: logger,
+	}
+
+	err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("NewReloader: %w", err)
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a server config which always serves the latest loaded files.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+
+			if r.clientCAs != nil {
+				config.ClientCAs = r.clientCAs
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+				if r.config.RequireClientCert {
+					config.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+
+			return config, nil
+		},
+	}
+}
+
+// Run checks the files every reload interval and reloads them once any of them changes.
+// A broken file keeps the previous certificates in use.
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+
+			err := r.load()
+			if err != nil {
+				r.logger.Error("Run: failed to reload certificates", zap.Error(err))
+				continue
+			}
+
+			r.logger.Info("Run: certificates reloaded")
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+
+	return files
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			r.logger.Warn("changed: failed to stat certificate file", zap.String("file", file), zap.Error(err))
+			return false
+		}
+
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Reloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("load: %w", err)
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load: failed to load key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		clientCAs, err = LoadCertPool(r.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("load: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// LoadCertPool reads PEM encoded CA certificates.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadCertPool: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("LoadCertPool: no certificates found in %s", path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+type Config struct {
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// Exporter is otlp, stdout or file, the last two work without a collector.
+	Exporter    string  `yaml:"exporter" env-default:"stdout"`
+	Endpoint    string  `yaml:"endpoint" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure" env-default:"true"`
+	File        string  `yaml:"file" env-default:"traces.json"`
+	ServiceName string  `yaml:"service_name" env-default:"file-service"`
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+// New installs the global tracer provider and the W3C trace context propagator. The returned function
+// flushes the spans which are not exported yet, it has to be called on shutdown.
+func New(ctx context.Context, config *Config, logger *zap.Logger) (func(ctx context.Context) error, error) {
+	if !config.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("New: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", config.ServiceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	logger.Info("New: tracing is enabled", zap.String("exporter", config.Exporter))
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closeErr := closer.Close()
+			if err == nil {
+				err = closeErr
+			}
+		}
+
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, config *Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newExporter: cannot create otlp exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("newExporter: cannot create stdout exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+
+	case ExporterFile:
+		f, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newExporter: cannot open trace file: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("newExporter: cannot create file exporter: %w", err)
+		}
+
+		return exporter, f, nil
+	}
+
+	return nil, nil, fmt.Errorf("newExporter: unknown exporter: %s", config.Exporter)
+}
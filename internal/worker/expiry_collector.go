@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"fileservice/internal/cleanup"
+	"fileservice/internal/sorage/postgres"
+)
+
+type ExpiryObjectStorage interface {
+	RemoveObject(ctx context.Context, id string) error
+}
+
+type ExpiryMetaStorage interface {
+	ListExpiredFiles(ctx context.Context, statuses []string, before time.Time, limit int) ([]*postgres.File, error)
+	MarkDeleting(ctx context.Context, id string, from ...string) error
+	ListFileVersions(ctx context.Context, id string) ([]*postgres.FileVersion, error)
+	DeleteFileInfo(ctx context.Context, id string) ([]string, error)
+	DeleteExpiredLinks(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ExpiryCollector deletes temporary files and links once they expire. The service hides expired files on its own,
+// so the collector only has to reclaim the space.
+type ExpiryCollector struct {
+	objectStorage ExpiryObjectStorage
+	metaStorage   ExpiryMetaStorage
+	interval      time.Duration
+	batchSize     int
+	logger        *zap.Logger
+}
+
+func NewExpiryCollector(objectStorage ExpiryObjectStorage, metaStorage ExpiryMetaStorage, config *Config, logger *zap.Logger) *ExpiryCollector {
+	return &ExpiryCollector{
+		objectStorage: objectStorage,
+		metaStorage:   metaStorage,
+		interval:      config.ExpiryInterval,
+		batchSize:     config.BatchSize,
+		logger:        logger,
+	}
+}
+
+func (c *ExpiryCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.collect(ctx)
+			c.collectLinks(ctx)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *ExpiryCollector) collect(ctx context.Context) {
+	var total int
+
+	// Pending uploads are left to the reconciler, it decides whether they have landed at all.
+	statuses := []string{postgres.StatusSuccess, postgres.StatusTrashed}
+
+	for {
+		files, err := c.metaStorage.ListExpiredFiles(ctx, statuses, time.Now().UTC(), c.batchSize)
+		if err != nil {
+			c.logger.Error("collect: failed to list expired files", zap.Error(err))
+			return
+		}
+
+		var removed int
+		for _, file := range files {
+			if c.remove(ctx, file) {
+				removed++
+			}
+		}
+
+		total += removed
+
+		if len(files) < c.batchSize || removed == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		c.logger.Info("collect: removed expired files", zap.Int("count", total))
+	}
+}
+
+func (c *ExpiryCollector) collectLinks(ctx context.Context) {
+	count, err := c.metaStorage.DeleteExpiredLinks(ctx, time.Now().UTC())
+	if err != nil {
+		c.logger.Error("collectLinks: failed to delete expired links", zap.Error(err))
+		return
+	}
+
+	if count > 0 {
+		c.logger.Info("collectLinks: removed expired links", zap.Int64("count", count))
+	}
+}
+
+func (c *ExpiryCollector) remove(ctx context.Context, file *postgres.File) bool {
+	id := file.Info.GetId()
+
+	err := c.metaStorage.MarkDeleting(ctx, id, postgres.StatusSuccess, postgres.StatusTrashed)
+	if err != nil {
+		if !errors.Is(err, postgres.ErrNotFound) {
+			c.logger.Error("remove: failed to mark file as deleting", zap.String("id", id), zap.Error(err))
+		}
+
+		return false
+	}
+
+	err = cleanup.RemoveFile(ctx, c.objectStorage, c.metaStorage, file, c.logger)
+	if err != nil {
+		c.logger.Error("remove: failed to remove expired file", zap.String("id", id), zap.Error(err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"time"
+)
+
+type Config struct {
+	SessionGCInterval time.Duration `yaml:"session_gc_interval" env-default:"10m"`
+	BatchSize         int           `yaml:"batch_size" env-default:"100"`
+
+	ReconcileInterval time.Duration `yaml:"reconcile_interval" env-default:"1h"`
+	// StaleUploadAge is how long a file may stay pending or deleting before the reconciler takes it over.
+	StaleUploadAge time.Duration `yaml:"stale_upload_age" env-default:"1h"`
+	// OrphanGracePeriod protects fresh objects whose rows are not written yet.
+	OrphanGracePeriod time.Duration `yaml:"orphan_grace_period" env-default:"1h"`
+	// DryRun only reports what the reconciler would fix.
+	DryRun bool `yaml:"dry_run" env-default:"false"`
+
+	PurgeInterval  time.Duration `yaml:"purge_interval" env-default:"1h"`
+	TrashRetention time.Duration `yaml:"trash_retention" env-default:"720h"`
+
+	ExpiryInterval time.Duration `yaml:"expiry_interval" env-default:"5m"`
+}
@@ -0,0 +1,256 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"fileservice/internal/cleanup"
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+)
+
+type ReconcileObjectStorage interface {
+	StatObject(ctx context.Context, id string) (int64, error)
+	RemoveObject(ctx context.Context, id string) error
+	WalkObjects(ctx context.Context, fn func(object minio.Object) error) error
+}
+
+type ReconcileMetaStorage interface {
+	ListStaleFiles(ctx context.Context, status string, before time.Time, limit int) ([]*postgres.File, error)
+	SetSuccessStatus(ctx context.Context, id string, size int64, checksum string, crc32c string, maxBytes int64) error
+	ListFileVersions(ctx context.Context, id string) ([]*postgres.FileVersion, error)
+	DeleteFileInfo(ctx context.Context, id string) ([]string, error)
+	ExistingObjectKeys(ctx context.Context, keys []string) (map[string]struct{}, error)
+}
+
+// Reconciler brings table_files and the bucket back in line after crashes:
+// it finishes or rolls back stale pending uploads, finishes interrupted deletes and removes objects no file refers to.
+type Reconciler struct {
+	objectStorage ReconcileObjectStorage
+	metaStorage   ReconcileMetaStorage
+	interval      time.Duration
+	staleAge      time.Duration
+	gracePeriod   time.Duration
+	batchSize     int
+	dryRun        bool
+	logger        *zap.Logger
+}
+
+// Report counts what a reconciliation pass has fixed, or would fix in dry run mode.
+type Report struct {
+	FinishedUploads   int
+	RolledBackUploads int
+	FinishedDeletes   int
+	OrphanObjects     int
+	Failures          int
+}
+
+func NewReconciler(objectStorage ReconcileObjectStorage, metaStorage ReconcileMetaStorage, config *Config, logger *zap.Logger) *Reconciler {
+	return &Reconciler{
+		objectStorage: objectStorage,
+		metaStorage:   metaStorage,
+		interval:      config.ReconcileInterval,
+		staleAge:      config.StaleUploadAge,
+		gracePeriod:   config.OrphanGracePeriod,
+		batchSize:     config.BatchSize,
+		dryRun:        config.DryRun,
+		logger:        logger,
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			report := r.Reconcile(ctx)
+
+			r.logger.Info("Reconcile: reconciliation finished",
+				zap.Bool("dry_run", r.dryRun),
+				zap.Int("finished_uploads", report.FinishedUploads),
+				zap.Int("rolled_back_uploads", report.RolledBackUploads),
+				zap.Int("finished_deletes", report.FinishedDeletes),
+				zap.Int("orphan_objects", report.OrphanObjects),
+				zap.Int("failures", report.Failures),
+			)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Reconcile makes a single pass over stale files and the bucket.
+func (r *Reconciler) Reconcile(ctx context.Context) *Report {
+	report := &Report{}
+	now := time.Now().UTC()
+
+	r.reconcileFiles(ctx, postgres.StatusPending, now.Add(-r.staleAge), report, r.reconcilePending)
+	r.reconcileFiles(ctx, postgres.StatusDeleting, now.Add(-r.staleAge), report, r.reconcileDeleting)
+
+	err := r.reconcileObjects(ctx, now.Add(-r.gracePeriod), report)
+	if err != nil {
+		r.logger.Error("Reconcile: failed to reconcile objects", zap.Error(err))
+		report.Failures++
+	}
+
+	return report
+}
+
+func (r *Reconciler) reconcileFiles(
+	ctx context.Context,
+	status string,
+	before time.Time,
+	report *Report,
+	fix func(ctx context.Context, file *postgres.File, report *Report) bool,
+) {
+	for {
+		files, err := r.metaStorage.ListStaleFiles(ctx, status, before, r.batchSize)
+		if err != nil {
+			r.logger.Error("reconcileFiles: failed to list stale files", zap.String("status", status), zap.Error(err))
+			report.Failures++
+			return
+		}
+
+		var fixed int
+		for _, file := range files {
+			if fix(ctx, file, report) {
+				fixed++
+			} else {
+				report.Failures++
+			}
+		}
+
+		// A dry run changes nothing, so the next batch would be the same one: only the oldest files are reported.
+		if len(files) < r.batchSize || fixed == 0 || r.dryRun {
+			return
+		}
+	}
+}
+
+// reconcilePending finishes an upload whose object has landed and rolls back the one without an object.
+// Checksums of a finished upload stay empty, VerifyFile fills them in.
+func (r *Reconciler) reconcilePending(ctx context.Context, file *postgres.File, report *Report) bool {
+	id := file.Info.GetId()
+
+	size, err := r.objectStorage.StatObject(ctx, file.ObjectKey)
+	switch {
+	case err == nil:
+		r.logger.Info("reconcilePending: finishing stale upload", zap.String("id", id), zap.Bool("dry_run", r.dryRun))
+		report.FinishedUploads++
+
+		if r.dryRun {
+			return true
+		}
+
+		// The content is already stored, so it is accounted without checking the quota.
+		err = r.metaStorage.SetSuccessStatus(ctx, id, size, "", "", 0)
+		if err != nil {
+			r.logger.Error("reconcilePending: failed to set success status", zap.String("id", id), zap.Error(err))
+			return false
+		}
+
+	case errors.Is(err, minio.ErrNotFound):
+		r.logger.Info("reconcilePending: rolling back stale upload", zap.String("id", id), zap.Bool("dry_run", r.dryRun))
+		report.RolledBackUploads++
+
+		if r.dryRun {
+			return true
+		}
+
+		err = cleanup.RemoveFileInfo(ctx, r.objectStorage, r.metaStorage, id, r.logger)
+		if err != nil {
+			r.logger.Error("reconcilePending: failed to roll back upload", zap.String("id", id), zap.Error(err))
+			return false
+		}
+
+	default:
+		r.logger.Error("reconcilePending: failed to stat object", zap.String("id", id), zap.Error(err))
+		return false
+	}
+
+	return true
+}
+
+// reconcileDeleting finishes a delete interrupted between removing the object and the row.
+func (r *Reconciler) reconcileDeleting(ctx context.Context, file *postgres.File, report *Report) bool {
+	id := file.Info.GetId()
+
+	r.logger.Info("reconcileDeleting: finishing stale delete", zap.String("id", id), zap.Bool("dry_run", r.dryRun))
+	report.FinishedDeletes++
+
+	if r.dryRun {
+		return true
+	}
+
+	err := cleanup.RemoveFile(ctx, r.objectStorage, r.metaStorage, file, r.logger)
+	if err != nil {
+		r.logger.Error("reconcileDeleting: failed to delete file", zap.String("id", id), zap.Error(err))
+		return false
+	}
+
+	return true
+}
+
+// reconcileObjects walks the bucket and removes objects older than the grace period which no file refers to.
+func (r *Reconciler) reconcileObjects(ctx context.Context, before time.Time, report *Report) error {
+	batch := make([]string, 0, r.batchSize)
+
+	err := r.objectStorage.WalkObjects(ctx, func(object minio.Object) error {
+		if !object.LastModified.Before(before) {
+			return nil
+		}
+
+		batch = append(batch, object.Key)
+		if len(batch) < r.batchSize {
+			return nil
+		}
+
+		err := r.removeOrphans(ctx, batch, report)
+		batch = batch[:0]
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		return r.removeOrphans(ctx, batch, report)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) removeOrphans(ctx context.Context, keys []string, report *Report) error {
+	existing, err := r.metaStorage.ExistingObjectKeys(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if _, ok := existing[key]; ok {
+			continue
+		}
+
+		r.logger.Warn("removeOrphans: object is not referenced by any file", zap.String("key", key), zap.Bool("dry_run", r.dryRun))
+		report.OrphanObjects++
+
+		if r.dryRun {
+			continue
+		}
+
+		err = r.objectStorage.RemoveObject(ctx, key)
+		if err != nil {
+			r.logger.Error("removeOrphans: failed to remove object", zap.String("key", key), zap.Error(err))
+			report.Failures++
+		}
+	}
+
+	return nil
+}
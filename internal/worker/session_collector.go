@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"fileservice/internal/sorage/minio"
+	"fileservice/internal/sorage/postgres"
+	"fileservice/internal/tenant"
+)
+
+type SessionObjectStorage interface {
+	AbortMultipartUpload(ctx context.Context, id string, uploadID string) error
+}
+
+type SessionMetaStorage interface {
+	ListExpiredUploadSessions(ctx context.Context, now time.Time, limit int) ([]*postgres.UploadSession, error)
+	DeleteUploadSession(ctx context.Context, id string) error
+}
+
+// SessionCollector removes expired upload sessions together with the parts already stored in the object storage.
+type SessionCollector struct {
+	objectStorage SessionObjectStorage
+	metaStorage   SessionMetaStorage
+	interval      time.Duration
+	batchSize     int
+	logger        *zap.Logger
+}
+
+func NewSessionCollector(objectStorage SessionObjectStorage, metaStorage SessionMetaStorage, config *Config, logger *zap.Logger) *SessionCollector {
+	return &SessionCollector{
+		objectStorage: objectStorage,
+		metaStorage:   metaStorage,
+		interval:      config.SessionGCInterval,
+		batchSize:     config.BatchSize,
+		logger:        logger,
+	}
+}
+
+func (c *SessionCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.collect(ctx)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *SessionCollector) collect(ctx context.Context) {
+	var total int
+
+	for {
+		sessions, err := c.metaStorage.ListExpiredUploadSessions(ctx, time.Now().UTC(), c.batchSize)
+		if err != nil {
+			c.logger.Error("collect: failed to list expired upload sessions", zap.Error(err))
+			return
+		}
+
+		var removed int
+		for _, session := range sessions {
+			if c.remove(ctx, session) {
+				removed++
+			}
+		}
+
+		total += removed
+
+		// A batch without progress means every session failed, the next tick will try again.
+		if len(sessions) < c.batchSize || removed == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		c.logger.Info("collect: removed expired upload sessions", zap.Int("count", total))
+	}
+}
+
+func (c *SessionCollector) remove(ctx context.Context, session *postgres.UploadSession) bool {
+	if session.Status == postgres.SessionStatusActive {
+		err := c.objectStorage.AbortMultipartUpload(ctx, tenant.ObjectKey(session.Tenant, session.FileID), session.UploadID)
+		if err != nil && !errors.Is(err, minio.ErrUploadNotFound) {
+			c.logger.Error("remove: failed to abort multipart upload", zap.String("session_id", session.ID), zap.Error(err))
+			return false
+		}
+	}
+
+	err := c.metaStorage.DeleteUploadSession(ctx, session.ID)
+	if err != nil {
+		c.logger.Error("remove: failed to delete upload session", zap.String("session_id", session.ID), zap.Error(err))
+		return false
+	}
+
+	return true
+}
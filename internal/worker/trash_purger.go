@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"fileservice/internal/cleanup"
+	"fileservice/internal/sorage/postgres"
+)
+
+type PurgeObjectStorage interface {
+	RemoveObject(ctx context.Context, id string) error
+}
+
+type PurgeMetaStorage interface {
+	ListTrashedFiles(ctx context.Context, before time.Time, limit int) ([]*postgres.File, error)
+	MarkDeleting(ctx context.Context, id string, from ...string) error
+	ListFileVersions(ctx context.Context, id string) ([]*postgres.FileVersion, error)
+	DeleteFileInfo(ctx context.Context, id string) ([]string, error)
+}
+
+// TrashPurger permanently removes files which have stayed in the trash longer than the retention period.
+type TrashPurger struct {
+	objectStorage PurgeObjectStorage
+	metaStorage   PurgeMetaStorage
+	interval      time.Duration
+	retention     time.Duration
+	batchSize     int
+	logger        *zap.Logger
+}
+
+func NewTrashPurger(objectStorage PurgeObjectStorage, metaStorage PurgeMetaStorage, config *Config, logger *zap.Logger) *TrashPurger {
+	return &TrashPurger{
+		objectStorage: objectStorage,
+		metaStorage:   metaStorage,
+		interval:      config.PurgeInterval,
+		retention:     config.TrashRetention,
+		batchSize:     config.BatchSize,
+		logger:        logger,
+	}
+}
+
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.purge(ctx)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+	var total int
+
+	for {
+		files, err := p.metaStorage.ListTrashedFiles(ctx, time.Now().UTC().Add(-p.retention), p.batchSize)
+		if err != nil {
+			p.logger.Error("purge: failed to list trashed files", zap.Error(err))
+			return
+		}
+
+		var purged int
+		for _, file := range files {
+			if p.remove(ctx, file) {
+				purged++
+			}
+		}
+
+		total += purged
+
+		if len(files) < p.batchSize || purged == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		p.logger.Info("purge: purged trashed files", zap.Int("count", total))
+	}
+}
+
+// remove marks the file as deleting first: a restore racing with the purge fails instead of bringing back a half removed file,
+// and an interrupted purge is finished by the reconciler.
+func (p *TrashPurger) remove(ctx context.Context, file *postgres.File) bool {
+	id := file.Info.GetId()
+
+	err := p.metaStorage.MarkDeleting(ctx, id, postgres.StatusTrashed)
+	if err != nil {
+		if !errors.Is(err, postgres.ErrNotFound) {
+			p.logger.Error("remove: failed to mark file as deleting", zap.String("id", id), zap.Error(err))
+		}
+
+		return false
+	}
+
+	err = cleanup.RemoveFile(ctx, p.objectStorage, p.metaStorage, file, p.logger)
+	if err != nil {
+		p.logger.Error("remove: failed to purge file", zap.String("id", id), zap.Error(err))
+		return false
+	}
+
+	return true
+}
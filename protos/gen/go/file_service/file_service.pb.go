@@ -0,0 +1,3906 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.0
+// source: file_service/file_service.proto
+
+package fileservicev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SortField int32
+
+const (
+	SortField_SORT_FIELD_CREATED_AT SortField = 0
+	SortField_SORT_FIELD_UPDATED_AT SortField = 1
+	SortField_SORT_FIELD_NAME       SortField = 2
+	SortField_SORT_FIELD_SIZE       SortField = 3
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_CREATED_AT",
+		1: "SORT_FIELD_UPDATED_AT",
+		2: "SORT_FIELD_NAME",
+		3: "SORT_FIELD_SIZE",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_CREATED_AT": 0,
+		"SORT_FIELD_UPDATED_AT": 1,
+		"SORT_FIELD_NAME":       2,
+		"SORT_FIELD_SIZE":       3,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_service_file_service_proto_enumTypes[0].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_file_service_file_service_proto_enumTypes[0]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{0}
+}
+
+type SortDirection int32
+
+const (
+	SortDirection_SORT_DIRECTION_DESC SortDirection = 0
+	SortDirection_SORT_DIRECTION_ASC  SortDirection = 1
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "SORT_DIRECTION_DESC",
+		1: "SORT_DIRECTION_ASC",
+	}
+	SortDirection_value = map[string]int32{
+		"SORT_DIRECTION_DESC": 0,
+		"SORT_DIRECTION_ASC":  1,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_service_file_service_proto_enumTypes[1].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_file_service_file_service_proto_enumTypes[1]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{1}
+}
+
+// The owner of a file has every permission, other principals only get the granted ones.
+// Files without an owner are accessible to everyone.
+type Permission int32
+
+const (
+	Permission_PERMISSION_UNSPECIFIED Permission = 0
+	// read allows GetFile, GetFileInfo, VerifyFile, ListFileVersions and listing the file.
+	Permission_PERMISSION_READ Permission = 1
+	// write allows UploadFileVersion and RollbackFile.
+	Permission_PERMISSION_WRITE Permission = 2
+	// delete allows DeleteFile, TrashFile and RestoreFile.
+	Permission_PERMISSION_DELETE Permission = 3
+)
+
+// Enum value maps for Permission.
+var (
+	Permission_name = map[int32]string{
+		0: "PERMISSION_UNSPECIFIED",
+		1: "PERMISSION_READ",
+		2: "PERMISSION_WRITE",
+		3: "PERMISSION_DELETE",
+	}
+	Permission_value = map[string]int32{
+		"PERMISSION_UNSPECIFIED": 0,
+		"PERMISSION_READ":        1,
+		"PERMISSION_WRITE":       2,
+		"PERMISSION_DELETE":      3,
+	}
+)
+
+func (x Permission) Enum() *Permission {
+	p := new(Permission)
+	*p = x
+	return p
+}
+
+func (x Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_service_file_service_proto_enumTypes[2].Descriptor()
+}
+
+func (Permission) Type() protoreflect.EnumType {
+	return &file_file_service_file_service_proto_enumTypes[2]
+}
+
+func (x Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Permission.Descriptor instead.
+func (Permission) EnumDescriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{2}
+}
+
+type GranteeType int32
+
+const (
+	GranteeType_GRANTEE_TYPE_USER  GranteeType = 0
+	GranteeType_GRANTEE_TYPE_GROUP GranteeType = 1
+)
+
+// Enum value maps for GranteeType.
+var (
+	GranteeType_name = map[int32]string{
+		0: "GRANTEE_TYPE_USER",
+		1: "GRANTEE_TYPE_GROUP",
+	}
+	GranteeType_value = map[string]int32{
+		"GRANTEE_TYPE_USER":  0,
+		"GRANTEE_TYPE_GROUP": 1,
+	}
+)
+
+func (x GranteeType) Enum() *GranteeType {
+	p := new(GranteeType)
+	*p = x
+	return p
+}
+
+func (x GranteeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GranteeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_service_file_service_proto_enumTypes[3].Descriptor()
+}
+
+func (GranteeType) Type() protoreflect.EnumType {
+	return &file_file_service_file_service_proto_enumTypes[3]
+}
+
+func (x GranteeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GranteeType.Descriptor instead.
+func (GranteeType) EnumDescriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{3}
+}
+
+// Checksums are hex encoded: sha256 is the SHA-256 digest, crc32c is the big-endian CRC-32C (Castagnoli) value.
+// When set in the first message they are checked against the received content.
+// expiresAt or ttl, also read from the first message, make the file temporary: it is deleted once it expires.
+type UploadFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Sha256        string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Crc32C        string                 `protobuf:"bytes,5,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileRequest) Reset() {
+	*x = UploadFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileRequest) ProtoMessage() {}
+
+func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadFileRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *UploadFileRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UploadFileRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type UploadFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=fileId,proto3" json:"fileId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileResponse) ProtoMessage() {}
+
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadFileResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ListFilesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int64                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// offset is kept for compatibility, page_token should be used to walk through all files.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// page_token is the next_page_token of the previous response, empty for the first page.
+	// The filters and the sort order must stay the same while walking through pages.
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	NamePrefix    string                 `protobuf:"bytes,5,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	NameContains  string                 `protobuf:"bytes,6,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	ContentType   string                 `protobuf:"bytes,9,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	MinSize       int64                  `protobuf:"varint,10,opt,name=min_size,json=minSize,proto3" json:"min_size,omitempty"`
+	// max_size equal to 0 means no upper bound.
+	MaxSize       int64         `protobuf:"varint,11,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	SortBy        SortField     `protobuf:"varint,12,opt,name=sort_by,json=sortBy,proto3,enum=file_service.SortField" json:"sort_by,omitempty"`
+	SortDirection SortDirection `protobuf:"varint,13,opt,name=sort_direction,json=sortDirection,proto3,enum=file_service.SortDirection" json:"sort_direction,omitempty"`
+	// Files which are still being uploaded are hidden unless include_pending is set or status asks for them.
+	// Trashed files are only listed when status is trashed.
+	IncludePending bool `protobuf:"varint,14,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListFilesRequest) Reset() {
+	*x = ListFilesRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesRequest) ProtoMessage() {}
+
+func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListFilesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetMinSize() int64 {
+	if x != nil {
+		return x.MinSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetSortBy() SortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return SortField_SORT_FIELD_CREATED_AT
+}
+
+func (x *ListFilesRequest) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_SORT_DIRECTION_DESC
+}
+
+func (x *ListFilesRequest) GetIncludePending() bool {
+	if x != nil {
+		return x.IncludePending
+	}
+	return false
+}
+
+type ListFilesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Files []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// next_page_token is empty when there are no more files.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesResponse) Reset() {
+	*x = ListFilesResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesResponse) ProtoMessage() {}
+
+func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListFilesResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListFilesResponse) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListFilesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type FileInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	Id          string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Size        int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType string                 `protobuf:"bytes,6,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Checksum    string                 `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Status      string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Crc32C      string                 `protobuf:"bytes,9,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	// deletedAt is set while the file is in the trash.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	// version is the current version of the file, the one returned by GetFile by default.
+	Version int32 `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	// expiresAt is set for temporary files.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// owner is the subject of the principal which uploaded the file, empty for files uploaded without authentication.
+	Owner         string `protobuf:"bytes,13,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_file_service_file_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FileInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *FileInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileInfo) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *FileInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FileInfo) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+func (x *FileInfo) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *FileInfo) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FileInfo) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *FileInfo) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type GetFileRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// offset is the first byte to return, 0 starts from the beginning of the file.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// length is the number of bytes to return, 0 reads up to the end of the file.
+	Length int64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	// include_trashed allows downloading a file from the trash.
+	IncludeTrashed bool `protobuf:"varint,4,opt,name=include_trashed,json=includeTrashed,proto3" json:"include_trashed,omitempty"`
+	// version selects an older version of the file, 0 returns the current one.
+	Version       int32 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileRequest) Reset() {
+	*x = GetFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileRequest) ProtoMessage() {}
+
+func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *GetFileRequest) GetIncludeTrashed() bool {
+	if x != nil {
+		return x.IncludeTrashed
+	}
+	return false
+}
+
+func (x *GetFileRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// The first message carries fileName and the range information, the following ones carry chunks.
+type GetFileResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FileName    string                 `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	Chunk       []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	TotalSize   int64                  `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	RangeStart  int64                  `protobuf:"varint,4,opt,name=rangeStart,proto3" json:"rangeStart,omitempty"`
+	RangeLength int64                  `protobuf:"varint,5,opt,name=rangeLength,proto3" json:"rangeLength,omitempty"`
+	// Checksums of the whole file, not only of the requested range.
+	Sha256        string `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Crc32C        string `protobuf:"bytes,7,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	Version       int32  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileResponse) Reset() {
+	*x = GetFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileResponse) ProtoMessage() {}
+
+func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileResponse.ProtoReflect.Descriptor instead.
+func (*GetFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetFileResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GetFileResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *GetFileResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *GetFileResponse) GetRangeStart() int64 {
+	if x != nil {
+		return x.RangeStart
+	}
+	return 0
+}
+
+func (x *GetFileResponse) GetRangeLength() int64 {
+	if x != nil {
+		return x.RangeLength
+	}
+	return 0
+}
+
+func (x *GetFileResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *GetFileResponse) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+func (x *GetFileResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// UploadFileVersionRequest carries a new content of an existing file in chunks. file_id, contentType and the checksums
+// are read from the first message of the stream, the later ones only carry chunks.
+type UploadFileVersionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Chunk  []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// contentType defaults to the content type of the current version.
+	ContentType   string `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Sha256        string `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Crc32C        string `protobuf:"bytes,5,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileVersionRequest) Reset() {
+	*x = UploadFileVersionRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileVersionRequest) ProtoMessage() {}
+
+func (x *UploadFileVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileVersionRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileVersionRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UploadFileVersionRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadFileVersionRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *UploadFileVersionRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadFileVersionRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *UploadFileVersionRequest) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+type UploadFileVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileVersionResponse) Reset() {
+	*x = UploadFileVersionResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileVersionResponse) ProtoMessage() {}
+
+func (x *UploadFileVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileVersionResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileVersionResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UploadFileVersionResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadFileVersionResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type FileVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Checksum      string                 `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Crc32C        string                 `protobuf:"bytes,5,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileVersion) Reset() {
+	*x = FileVersion{}
+	mi := &file_file_service_file_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersion) ProtoMessage() {}
+
+func (x *FileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileVersion.ProtoReflect.Descriptor instead.
+func (*FileVersion) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FileVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FileVersion) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileVersion) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileVersion) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *FileVersion) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+func (x *FileVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListFileVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsRequest) Reset() {
+	*x = ListFileVersionsRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsRequest) ProtoMessage() {}
+
+func (x *ListFileVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListFileVersionsRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ListFileVersionsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Versions       []*FileVersion         `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	CurrentVersion int32                  `protobuf:"varint,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsResponse) Reset() {
+	*x = ListFileVersionsResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsResponse) ProtoMessage() {}
+
+func (x *ListFileVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListFileVersionsResponse) GetVersions() []*FileVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListFileVersionsResponse) GetCurrentVersion() int32 {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return 0
+}
+
+// RollbackFile makes an older version current again, newer versions are kept.
+type RollbackFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackFileRequest) Reset() {
+	*x = RollbackFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackFileRequest) ProtoMessage() {}
+
+func (x *RollbackFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackFileRequest.ProtoReflect.Descriptor instead.
+func (*RollbackFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RollbackFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RollbackFileRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RollbackFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackFileResponse) Reset() {
+	*x = RollbackFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackFileResponse) ProtoMessage() {}
+
+func (x *RollbackFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackFileResponse.ProtoReflect.Descriptor instead.
+func (*RollbackFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{13}
+}
+
+// TrashFile moves a file to the trash, it is purged for good once the retention period is over.
+type TrashFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashFileRequest) Reset() {
+	*x = TrashFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashFileRequest) ProtoMessage() {}
+
+func (x *TrashFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashFileRequest.ProtoReflect.Descriptor instead.
+func (*TrashFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TrashFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type TrashFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashFileResponse) Reset() {
+	*x = TrashFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashFileResponse) ProtoMessage() {}
+
+func (x *TrashFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashFileResponse.ProtoReflect.Descriptor instead.
+func (*TrashFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{15}
+}
+
+type RestoreFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileRequest) Reset() {
+	*x = RestoreFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileRequest) ProtoMessage() {}
+
+func (x *RestoreFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestoreFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type RestoreFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileResponse) Reset() {
+	*x = RestoreFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileResponse) ProtoMessage() {}
+
+func (x *RestoreFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{17}
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int64                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTrashRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTrashResponse) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type AccessEntry struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	GranteeType GranteeType            `protobuf:"varint,1,opt,name=grantee_type,json=granteeType,proto3,enum=file_service.GranteeType" json:"grantee_type,omitempty"`
+	// grantee is the subject of a user or the name of a group.
+	Grantee       string                 `protobuf:"bytes,2,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Permission    Permission             `protobuf:"varint,3,opt,name=permission,proto3,enum=file_service.Permission" json:"permission,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessEntry) Reset() {
+	*x = AccessEntry{}
+	mi := &file_file_service_file_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessEntry) ProtoMessage() {}
+
+func (x *AccessEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessEntry.ProtoReflect.Descriptor instead.
+func (*AccessEntry) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AccessEntry) GetGranteeType() GranteeType {
+	if x != nil {
+		return x.GranteeType
+	}
+	return GranteeType_GRANTEE_TYPE_USER
+}
+
+func (x *AccessEntry) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+func (x *AccessEntry) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_PERMISSION_UNSPECIFIED
+}
+
+func (x *AccessEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// GrantAccess and RevokeAccess are only allowed to the owner of the file.
+type GrantAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	GranteeType   GranteeType            `protobuf:"varint,2,opt,name=grantee_type,json=granteeType,proto3,enum=file_service.GranteeType" json:"grantee_type,omitempty"`
+	Grantee       string                 `protobuf:"bytes,3,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Permissions   []Permission           `protobuf:"varint,4,rep,packed,name=permissions,proto3,enum=file_service.Permission" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantAccessRequest) Reset() {
+	*x = GrantAccessRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantAccessRequest) ProtoMessage() {}
+
+func (x *GrantAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantAccessRequest.ProtoReflect.Descriptor instead.
+func (*GrantAccessRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GrantAccessRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) GetGranteeType() GranteeType {
+	if x != nil {
+		return x.GranteeType
+	}
+	return GranteeType_GRANTEE_TYPE_USER
+}
+
+func (x *GrantAccessRequest) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) GetPermissions() []Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type GrantAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantAccessResponse) Reset() {
+	*x = GrantAccessResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantAccessResponse) ProtoMessage() {}
+
+func (x *GrantAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantAccessResponse.ProtoReflect.Descriptor instead.
+func (*GrantAccessResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{22}
+}
+
+// RevokeAccessRequest with empty permissions revokes everything granted to the grantee.
+type RevokeAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	GranteeType   GranteeType            `protobuf:"varint,2,opt,name=grantee_type,json=granteeType,proto3,enum=file_service.GranteeType" json:"grantee_type,omitempty"`
+	Grantee       string                 `protobuf:"bytes,3,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Permissions   []Permission           `protobuf:"varint,4,rep,packed,name=permissions,proto3,enum=file_service.Permission" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessRequest) Reset() {
+	*x = RevokeAccessRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessRequest) ProtoMessage() {}
+
+func (x *RevokeAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAccessRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RevokeAccessRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RevokeAccessRequest) GetGranteeType() GranteeType {
+	if x != nil {
+		return x.GranteeType
+	}
+	return GranteeType_GRANTEE_TYPE_USER
+}
+
+func (x *RevokeAccessRequest) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+func (x *RevokeAccessRequest) GetPermissions() []Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type RevokeAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessResponse) Reset() {
+	*x = RevokeAccessResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessResponse) ProtoMessage() {}
+
+func (x *RevokeAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAccessResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{24}
+}
+
+type ListAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessRequest) Reset() {
+	*x = ListAccessRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessRequest) ProtoMessage() {}
+
+func (x *ListAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListAccessRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ListAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Entries       []*AccessEntry         `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessResponse) Reset() {
+	*x = ListAccessResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessResponse) ProtoMessage() {}
+
+func (x *ListAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListAccessResponse) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ListAccessResponse) GetEntries() []*AccessEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{27}
+}
+
+// GetUsageResponse reports the usage of the tenant of the caller, bytes count every stored version.
+// A limit equal to 0 means no limit.
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Files         int64                  `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	MaxBytes      int64                  `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	MaxFiles      int64                  `protobuf:"varint,5,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetUsageResponse) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetUsageResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetFiles() int64 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetMaxFiles() int64 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+type CreateDownloadLinkRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// ttl defaults to the configured one when not set.
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// max_uses zero allows any number of downloads until the link expires.
+	MaxUses int32 `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// attachment makes browsers save the file under its stored name instead of displaying it.
+	Attachment    bool `protobuf:"varint,4,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadLinkRequest) Reset() {
+	*x = CreateDownloadLinkRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadLinkRequest) ProtoMessage() {}
+
+func (x *CreateDownloadLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateDownloadLinkRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateDownloadLinkRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CreateDownloadLinkRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *CreateDownloadLinkRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateDownloadLinkRequest) GetAttachment() bool {
+	if x != nil {
+		return x.Attachment
+	}
+	return false
+}
+
+type CreateDownloadLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadLinkResponse) Reset() {
+	*x = CreateDownloadLinkResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadLinkResponse) ProtoMessage() {}
+
+func (x *CreateDownloadLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateDownloadLinkResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateDownloadLinkResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateDownloadLinkResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Every use of an upload link creates a new file, its content is the body of a PUT request.
+type CreateUploadLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	MaxUses       int32                  `protobuf:"varint,4,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadLinkRequest) Reset() {
+	*x = CreateUploadLinkRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadLinkRequest) ProtoMessage() {}
+
+func (x *CreateUploadLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadLinkRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateUploadLinkRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CreateUploadLinkRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *CreateUploadLinkRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *CreateUploadLinkRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+type CreateUploadLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadLinkResponse) Reset() {
+	*x = CreateUploadLinkResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadLinkResponse) ProtoMessage() {}
+
+func (x *CreateUploadLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateUploadLinkResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateUploadLinkResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateUploadLinkResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{34}
+}
+
+type GetFileInfoRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// check_object additionally checks that the object is present in the object storage.
+	CheckObject   bool `protobuf:"varint,2,opt,name=check_object,json=checkObject,proto3" json:"check_object,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileInfoRequest) Reset() {
+	*x = GetFileInfoRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileInfoRequest) ProtoMessage() {}
+
+func (x *GetFileInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetFileInfoRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetFileInfoRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetFileInfoRequest) GetCheckObject() bool {
+	if x != nil {
+		return x.CheckObject
+	}
+	return false
+}
+
+type GetFileInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	File  *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// objectExists is filled only when check_object is set.
+	ObjectExists  bool `protobuf:"varint,2,opt,name=objectExists,proto3" json:"objectExists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileInfoResponse) Reset() {
+	*x = GetFileInfoResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileInfoResponse) ProtoMessage() {}
+
+func (x *GetFileInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetFileInfoResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetFileInfoResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *GetFileInfoResponse) GetObjectExists() bool {
+	if x != nil {
+		return x.ObjectExists
+	}
+	return false
+}
+
+type InitiateUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateUploadRequest) Reset() {
+	*x = InitiateUploadRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateUploadRequest) ProtoMessage() {}
+
+func (x *InitiateUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateUploadRequest.ProtoReflect.Descriptor instead.
+func (*InitiateUploadRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *InitiateUploadRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *InitiateUploadRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type InitiateUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateUploadResponse) Reset() {
+	*x = InitiateUploadResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateUploadResponse) ProtoMessage() {}
+
+func (x *InitiateUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateUploadResponse.ProtoReflect.Descriptor instead.
+func (*InitiateUploadResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *InitiateUploadResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *InitiateUploadResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// The first message of the stream must carry session_id, part_number and size,
+// the following ones may carry only chunks. All parts except the last one must be at least 5 MiB.
+type UploadPartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	PartNumber    int32                  `protobuf:"varint,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadPartRequest) Reset() {
+	*x = UploadPartRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPartRequest) ProtoMessage() {}
+
+func (x *UploadPartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPartRequest.ProtoReflect.Descriptor instead.
+func (*UploadPartRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UploadPartRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *UploadPartRequest) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPartRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadPartRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type UploadPartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadPartResponse) Reset() {
+	*x = UploadPartResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPartResponse) ProtoMessage() {}
+
+func (x *UploadPartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPartResponse.ProtoReflect.Descriptor instead.
+func (*UploadPartResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UploadPartResponse) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPartResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetUploadStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadStatusRequest) Reset() {
+	*x = GetUploadStatusRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadStatusRequest) ProtoMessage() {}
+
+func (x *GetUploadStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetUploadStatusRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type UploadedPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=uploadedAt,proto3" json:"uploadedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadedPart) Reset() {
+	*x = UploadedPart{}
+	mi := &file_file_service_file_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadedPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadedPart) ProtoMessage() {}
+
+func (x *UploadedPart) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadedPart.ProtoReflect.Descriptor instead.
+func (*UploadedPart) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UploadedPart) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadedPart) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadedPart) GetUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return nil
+}
+
+type GetUploadStatusResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	FileName  string                 `protobuf:"bytes,2,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	Status    string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Parts     []*UploadedPart        `protobuf:"bytes,4,rep,name=parts,proto3" json:"parts,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// fileId is filled once the upload is completed.
+	FileId        string `protobuf:"bytes,6,opt,name=fileId,proto3" json:"fileId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadStatusResponse) Reset() {
+	*x = GetUploadStatusResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadStatusResponse) ProtoMessage() {}
+
+func (x *GetUploadStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetUploadStatusResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetParts() []*UploadedPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *GetUploadStatusResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *GetUploadStatusResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type CompleteUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUploadRequest) Reset() {
+	*x = CompleteUploadRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadRequest) ProtoMessage() {}
+
+func (x *CompleteUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadRequest.ProtoReflect.Descriptor instead.
+func (*CompleteUploadRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CompleteUploadRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type CompleteUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=fileId,proto3" json:"fileId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUploadResponse) Reset() {
+	*x = CompleteUploadResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadResponse) ProtoMessage() {}
+
+func (x *CompleteUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadResponse.ProtoReflect.Descriptor instead.
+func (*CompleteUploadResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CompleteUploadResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type VerifyFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyFileRequest) Reset() {
+	*x = VerifyFileRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyFileRequest) ProtoMessage() {}
+
+func (x *VerifyFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyFileRequest.ProtoReflect.Descriptor instead.
+func (*VerifyFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *VerifyFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+// VerifyFileResponse compares the stored checksums with the ones computed from the stored object.
+type VerifyFileResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Valid          bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	ExpectedSha256 string                 `protobuf:"bytes,2,opt,name=expectedSha256,proto3" json:"expectedSha256,omitempty"`
+	ActualSha256   string                 `protobuf:"bytes,3,opt,name=actualSha256,proto3" json:"actualSha256,omitempty"`
+	ExpectedCrc32C string                 `protobuf:"bytes,4,opt,name=expectedCrc32c,proto3" json:"expectedCrc32c,omitempty"`
+	ActualCrc32C   string                 `protobuf:"bytes,5,opt,name=actualCrc32c,proto3" json:"actualCrc32c,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyFileResponse) Reset() {
+	*x = VerifyFileResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyFileResponse) ProtoMessage() {}
+
+func (x *VerifyFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyFileResponse.ProtoReflect.Descriptor instead.
+func (*VerifyFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *VerifyFileResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyFileResponse) GetExpectedSha256() string {
+	if x != nil {
+		return x.ExpectedSha256
+	}
+	return ""
+}
+
+func (x *VerifyFileResponse) GetActualSha256() string {
+	if x != nil {
+		return x.ActualSha256
+	}
+	return ""
+}
+
+func (x *VerifyFileResponse) GetExpectedCrc32C() string {
+	if x != nil {
+		return x.ExpectedCrc32C
+	}
+	return ""
+}
+
+func (x *VerifyFileResponse) GetActualCrc32C() string {
+	if x != nil {
+		return x.ActualCrc32C
+	}
+	return ""
+}
+
+type Tenant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id consists of lowercase letters, digits and dashes, it is sent in the x-tenant-id metadata.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Calls of a disabled tenant are rejected, its files are kept.
+	Disabled      bool                   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_file_service_file_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Tenant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Tenant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTenantRequest) Reset() {
+	*x = CreateTenantRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantRequest) ProtoMessage() {}
+
+func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
+func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateTenantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        *Tenant                `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTenantResponse) Reset() {
+	*x = CreateTenantResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantResponse) ProtoMessage() {}
+
+func (x *CreateTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantResponse.ProtoReflect.Descriptor instead.
+func (*CreateTenantResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateTenantResponse) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+type ListTenantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{51}
+}
+
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+type DisableTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTenantRequest) Reset() {
+	*x = DisableTenantRequest{}
+	mi := &file_file_service_file_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTenantRequest) ProtoMessage() {}
+
+func (x *DisableTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTenantRequest.ProtoReflect.Descriptor instead.
+func (*DisableTenantRequest) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DisableTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DisableTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTenantResponse) Reset() {
+	*x = DisableTenantResponse{}
+	mi := &file_file_service_file_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTenantResponse) ProtoMessage() {}
+
+func (x *DisableTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_service_file_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTenantResponse.ProtoReflect.Descriptor instead.
+func (*DisableTenantResponse) Descriptor() ([]byte, []int) {
+	return file_file_service_file_service_proto_rawDescGZIP(), []int{54}
+}
+
+var File_file_service_file_service_proto protoreflect.FileDescriptor
+
+const file_file_service_file_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1ffile_service/file_service.proto\x12\ffile_service\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfe\x01\n" +
+	"\x11UploadFileRequest\x12\x1a\n" +
+	"\bfileName\x18\x01 \x01(\tR\bfileName\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\x12 \n" +
+	"\vcontentType\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x16\n" +
+	"\x06crc32c\x18\x05 \x01(\tR\x06crc32c\x128\n" +
+	"\texpiresAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12+\n" +
+	"\x03ttl\x18\a \x01(\v2\x19.google.protobuf.DurationR\x03ttl\",\n" +
+	"\x12UploadFileResponse\x12\x16\n" +
+	"\x06fileId\x18\x01 \x01(\tR\x06fileId\"\xb9\x04\n" +
+	"\x10ListFilesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
+	"\vname_prefix\x18\x05 \x01(\tR\n" +
+	"namePrefix\x12#\n" +
+	"\rname_contains\x18\x06 \x01(\tR\fnameContains\x12?\n" +
+	"\rcreated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12!\n" +
+	"\fcontent_type\x18\t \x01(\tR\vcontentType\x12\x19\n" +
+	"\bmin_size\x18\n" +
+	" \x01(\x03R\aminSize\x12\x19\n" +
+	"\bmax_size\x18\v \x01(\x03R\amaxSize\x120\n" +
+	"\asort_by\x18\f \x01(\x0e2\x17.file_service.SortFieldR\x06sortBy\x12B\n" +
+	"\x0esort_direction\x18\r \x01(\x0e2\x1b.file_service.SortDirectionR\rsortDirection\x12'\n" +
+	"\x0finclude_pending\x18\x0e \x01(\bR\x0eincludePending\"i\n" +
+	"\x11ListFilesResponse\x12,\n" +
+	"\x05files\x18\x01 \x03(\v2\x16.file_service.FileInfoR\x05files\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc8\x03\n" +
+	"\bFileInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\tcreatedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12 \n" +
+	"\vcontentType\x18\x06 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bchecksum\x18\a \x01(\tR\bchecksum\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x16\n" +
+	"\x06crc32c\x18\t \x01(\tR\x06crc32c\x128\n" +
+	"\tdeletedAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversion\x128\n" +
+	"\texpiresAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x14\n" +
+	"\x05owner\x18\r \x01(\tR\x05owner\"\x9c\x01\n" +
+	"\x0eGetFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\x12'\n" +
+	"\x0finclude_trashed\x18\x04 \x01(\bR\x0eincludeTrashed\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\"\xed\x01\n" +
+	"\x0fGetFileResponse\x12\x1a\n" +
+	"\bfileName\x18\x01 \x01(\tR\bfileName\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\x12\x1c\n" +
+	"\ttotalSize\x18\x03 \x01(\x03R\ttotalSize\x12\x1e\n" +
+	"\n" +
+	"rangeStart\x18\x04 \x01(\x03R\n" +
+	"rangeStart\x12 \n" +
+	"\vrangeLength\x18\x05 \x01(\x03R\vrangeLength\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12\x16\n" +
+	"\x06crc32c\x18\a \x01(\tR\x06crc32c\x12\x18\n" +
+	"\aversion\x18\b \x01(\x05R\aversion\"\x9b\x01\n" +
+	"\x18UploadFileVersionRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\x12 \n" +
+	"\vcontentType\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x16\n" +
+	"\x06crc32c\x18\x05 \x01(\tR\x06crc32c\"N\n" +
+	"\x19UploadFileVersionResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\xcb\x01\n" +
+	"\vFileVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12 \n" +
+	"\vcontentType\x18\x03 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bchecksum\x18\x04 \x01(\tR\bchecksum\x12\x16\n" +
+	"\x06crc32c\x18\x05 \x01(\tR\x06crc32c\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"2\n" +
+	"\x17ListFileVersionsRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"z\n" +
+	"\x18ListFileVersionsResponse\x125\n" +
+	"\bversions\x18\x01 \x03(\v2\x19.file_service.FileVersionR\bversions\x12'\n" +
+	"\x0fcurrent_version\x18\x02 \x01(\x05R\x0ecurrentVersion\"H\n" +
+	"\x13RollbackFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x16\n" +
+	"\x14RollbackFileResponse\"+\n" +
+	"\x10TrashFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\x13\n" +
+	"\x11TrashFileResponse\"-\n" +
+	"\x12RestoreFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\x15\n" +
+	"\x13RestoreFileResponse\"G\n" +
+	"\x10ListTrashRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"i\n" +
+	"\x11ListTrashResponse\x12,\n" +
+	"\x05files\x18\x01 \x03(\v2\x16.file_service.FileInfoR\x05files\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd9\x01\n" +
+	"\vAccessEntry\x12<\n" +
+	"\fgrantee_type\x18\x01 \x01(\x0e2\x19.file_service.GranteeTypeR\vgranteeType\x12\x18\n" +
+	"\agrantee\x18\x02 \x01(\tR\agrantee\x128\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x18.file_service.PermissionR\n" +
+	"permission\x128\n" +
+	"\tcreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc1\x01\n" +
+	"\x12GrantAccessRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12<\n" +
+	"\fgrantee_type\x18\x02 \x01(\x0e2\x19.file_service.GranteeTypeR\vgranteeType\x12\x18\n" +
+	"\agrantee\x18\x03 \x01(\tR\agrantee\x12:\n" +
+	"\vpermissions\x18\x04 \x03(\x0e2\x18.file_service.PermissionR\vpermissions\"\x15\n" +
+	"\x13GrantAccessResponse\"\xc2\x01\n" +
+	"\x13RevokeAccessRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12<\n" +
+	"\fgrantee_type\x18\x02 \x01(\x0e2\x19.file_service.GranteeTypeR\vgranteeType\x12\x18\n" +
+	"\agrantee\x18\x03 \x01(\tR\agrantee\x12:\n" +
+	"\vpermissions\x18\x04 \x03(\x0e2\x18.file_service.PermissionR\vpermissions\"\x16\n" +
+	"\x14RevokeAccessResponse\",\n" +
+	"\x11ListAccessRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"_\n" +
+	"\x12ListAccessResponse\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x123\n" +
+	"\aentries\x18\x02 \x03(\v2\x19.file_service.AccessEntryR\aentries\"\x11\n" +
+	"\x0fGetUsageRequest\"\x90\x01\n" +
+	"\x10GetUsageResponse\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x14\n" +
+	"\x05files\x18\x03 \x01(\x03R\x05files\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\x03R\bmaxBytes\x12\x1b\n" +
+	"\tmax_files\x18\x05 \x01(\x03R\bmaxFiles\"\x9c\x01\n" +
+	"\x19CreateDownloadLinkRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x19\n" +
+	"\bmax_uses\x18\x03 \x01(\x05R\amaxUses\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x04 \x01(\bR\n" +
+	"attachment\"i\n" +
+	"\x1aCreateDownloadLinkResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xa1\x01\n" +
+	"\x17CreateUploadLinkRequest\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x19\n" +
+	"\bmax_uses\x18\x04 \x01(\x05R\amaxUses\"g\n" +
+	"\x18CreateUploadLinkResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\",\n" +
+	"\x11DeleteFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\x14\n" +
+	"\x12DeleteFileResponse\"P\n" +
+	"\x12GetFileInfoRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12!\n" +
+	"\fcheck_object\x18\x02 \x01(\bR\vcheckObject\"e\n" +
+	"\x13GetFileInfoResponse\x12*\n" +
+	"\x04file\x18\x01 \x01(\v2\x16.file_service.FileInfoR\x04file\x12\"\n" +
+	"\fobjectExists\x18\x02 \x01(\bR\fobjectExists\"U\n" +
+	"\x15InitiateUploadRequest\x12\x1a\n" +
+	"\bfileName\x18\x01 \x01(\tR\bfileName\x12 \n" +
+	"\vcontentType\x18\x02 \x01(\tR\vcontentType\"q\n" +
+	"\x16InitiateUploadResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x128\n" +
+	"\texpiresAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"}\n" +
+	"\x11UploadPartRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
+	"\vpart_number\x18\x02 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05chunk\x18\x04 \x01(\fR\x05chunk\"I\n" +
+	"\x12UploadPartResponse\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"7\n" +
+	"\x16GetUploadStatusRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x7f\n" +
+	"\fUploadedPart\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12:\n" +
+	"\n" +
+	"uploadedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"uploadedAt\"\xf0\x01\n" +
+	"\x17GetUploadStatusResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\bfileName\x18\x02 \x01(\tR\bfileName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x120\n" +
+	"\x05parts\x18\x04 \x03(\v2\x1a.file_service.UploadedPartR\x05parts\x128\n" +
+	"\texpiresAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06fileId\x18\x06 \x01(\tR\x06fileId\"6\n" +
+	"\x15CompleteUploadRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"0\n" +
+	"\x16CompleteUploadResponse\x12\x16\n" +
+	"\x06fileId\x18\x01 \x01(\tR\x06fileId\",\n" +
+	"\x11VerifyFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\xc2\x01\n" +
+	"\x12VerifyFileResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12&\n" +
+	"\x0eexpectedSha256\x18\x02 \x01(\tR\x0eexpectedSha256\x12\"\n" +
+	"\factualSha256\x18\x03 \x01(\tR\factualSha256\x12&\n" +
+	"\x0eexpectedCrc32c\x18\x04 \x01(\tR\x0eexpectedCrc32c\x12\"\n" +
+	"\factualCrc32c\x18\x05 \x01(\tR\factualCrc32c\"\x82\x01\n" +
+	"\x06Tenant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bdisabled\x18\x03 \x01(\bR\bdisabled\x128\n" +
+	"\tcreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"9\n" +
+	"\x13CreateTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"D\n" +
+	"\x14CreateTenantResponse\x12,\n" +
+	"\x06tenant\x18\x01 \x01(\v2\x14.file_service.TenantR\x06tenant\"\x14\n" +
+	"\x12ListTenantsRequest\"E\n" +
+	"\x13ListTenantsResponse\x12.\n" +
+	"\atenants\x18\x01 \x03(\v2\x14.file_service.TenantR\atenants\"&\n" +
+	"\x14DisableTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DisableTenantResponse*k\n" +
+	"\tSortField\x12\x19\n" +
+	"\x15SORT_FIELD_CREATED_AT\x10\x00\x12\x19\n" +
+	"\x15SORT_FIELD_UPDATED_AT\x10\x01\x12\x13\n" +
+	"\x0fSORT_FIELD_NAME\x10\x02\x12\x13\n" +
+	"\x0fSORT_FIELD_SIZE\x10\x03*@\n" +
+	"\rSortDirection\x12\x17\n" +
+	"\x13SORT_DIRECTION_DESC\x10\x00\x12\x16\n" +
+	"\x12SORT_DIRECTION_ASC\x10\x01*j\n" +
+	"\n" +
+	"Permission\x12\x1a\n" +
+	"\x16PERMISSION_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fPERMISSION_READ\x10\x01\x12\x14\n" +
+	"\x10PERMISSION_WRITE\x10\x02\x12\x15\n" +
+	"\x11PERMISSION_DELETE\x10\x03*<\n" +
+	"\vGranteeType\x12\x15\n" +
+	"\x11GRANTEE_TYPE_USER\x10\x00\x12\x16\n" +
+	"\x12GRANTEE_TYPE_GROUP\x10\x012\x80\x0f\n" +
+	"\vFileService\x12Q\n" +
+	"\n" +
+	"UploadFile\x12\x1f.file_service.UploadFileRequest\x1a .file_service.UploadFileResponse(\x01\x12L\n" +
+	"\tListFiles\x12\x1e.file_service.ListFilesRequest\x1a\x1f.file_service.ListFilesResponse\x12H\n" +
+	"\aGetFile\x12\x1c.file_service.GetFileRequest\x1a\x1d.file_service.GetFileResponse0\x01\x12O\n" +
+	"\n" +
+	"DeleteFile\x12\x1f.file_service.DeleteFileRequest\x1a .file_service.DeleteFileResponse\x12R\n" +
+	"\vGetFileInfo\x12 .file_service.GetFileInfoRequest\x1a!.file_service.GetFileInfoResponse\x12[\n" +
+	"\x0eInitiateUpload\x12#.file_service.InitiateUploadRequest\x1a$.file_service.InitiateUploadResponse\x12Q\n" +
+	"\n" +
+	"UploadPart\x12\x1f.file_service.UploadPartRequest\x1a .file_service.UploadPartResponse(\x01\x12^\n" +
+	"\x0fGetUploadStatus\x12$.file_service.GetUploadStatusRequest\x1a%.file_service.GetUploadStatusResponse\x12[\n" +
+	"\x0eCompleteUpload\x12#.file_service.CompleteUploadRequest\x1a$.file_service.CompleteUploadResponse\x12O\n" +
+	"\n" +
+	"VerifyFile\x12\x1f.file_service.VerifyFileRequest\x1a .file_service.VerifyFileResponse\x12L\n" +
+	"\tTrashFile\x12\x1e.file_service.TrashFileRequest\x1a\x1f.file_service.TrashFileResponse\x12R\n" +
+	"\vRestoreFile\x12 .file_service.RestoreFileRequest\x1a!.file_service.RestoreFileResponse\x12L\n" +
+	"\tListTrash\x12\x1e.file_service.ListTrashRequest\x1a\x1f.file_service.ListTrashResponse\x12f\n" +
+	"\x11UploadFileVersion\x12&.file_service.UploadFileVersionRequest\x1a'.file_service.UploadFileVersionResponse(\x01\x12a\n" +
+	"\x10ListFileVersions\x12%.file_service.ListFileVersionsRequest\x1a&.file_service.ListFileVersionsResponse\x12U\n" +
+	"\fRollbackFile\x12!.file_service.RollbackFileRequest\x1a\".file_service.RollbackFileResponse\x12R\n" +
+	"\vGrantAccess\x12 .file_service.GrantAccessRequest\x1a!.file_service.GrantAccessResponse\x12U\n" +
+	"\fRevokeAccess\x12!.file_service.RevokeAccessRequest\x1a\".file_service.RevokeAccessResponse\x12O\n" +
+	"\n" +
+	"ListAccess\x12\x1f.file_service.ListAccessRequest\x1a .file_service.ListAccessResponse\x12I\n" +
+	"\bGetUsage\x12\x1d.file_service.GetUsageRequest\x1a\x1e.file_service.GetUsageResponse\x12g\n" +
+	"\x12CreateDownloadLink\x12'.file_service.CreateDownloadLinkRequest\x1a(.file_service.CreateDownloadLinkResponse\x12a\n" +
+	"\x10CreateUploadLink\x12%.file_service.CreateUploadLinkRequest\x1a&.file_service.CreateUploadLinkResponse2\x94\x02\n" +
+	"\rTenantService\x12U\n" +
+	"\fCreateTenant\x12!.file_service.CreateTenantRequest\x1a\".file_service.CreateTenantResponse\x12R\n" +
+	"\vListTenants\x12 .file_service.ListTenantsRequest\x1a!.file_service.ListTenantsResponse\x12X\n" +
+	"\rDisableTenant\x12\".file_service.DisableTenantRequest\x1a#.file_service.DisableTenantResponseB\x1fZ\x1dfile_service.v1;fileservicev1b\x06proto3"
+
+var (
+	file_file_service_file_service_proto_rawDescOnce sync.Once
+	file_file_service_file_service_proto_rawDescData []byte
+)
+
+func file_file_service_file_service_proto_rawDescGZIP() []byte {
+	file_file_service_file_service_proto_rawDescOnce.Do(func() {
+		file_file_service_file_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_file_service_file_service_proto_rawDesc), len(file_file_service_file_service_proto_rawDesc)))
+	})
+	return file_file_service_file_service_proto_rawDescData
+}
+
+var file_file_service_file_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_file_service_file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
+var file_file_service_file_service_proto_goTypes = []any{
+	(SortField)(0),                     // 0: file_service.SortField
+	(SortDirection)(0),                 // 1: file_service.SortDirection
+	(Permission)(0),                    // 2: file_service.Permission
+	(GranteeType)(0),                   // 3: file_service.GranteeType
+	(*UploadFileRequest)(nil),          // 4: file_service.UploadFileRequest
+	(*UploadFileResponse)(nil),         // 5: file_service.UploadFileResponse
+	(*ListFilesRequest)(nil),           // 6: file_service.ListFilesRequest
+	(*ListFilesResponse)(nil),          // 7: file_service.ListFilesResponse
+	(*FileInfo)(nil),                   // 8: file_service.FileInfo
+	(*GetFileRequest)(nil),             // 9: file_service.GetFileRequest
+	(*GetFileResponse)(nil),            // 10: file_service.GetFileResponse
+	(*UploadFileVersionRequest)(nil),   // 11: file_service.UploadFileVersionRequest
+	(*UploadFileVersionResponse)(nil),  // 12: file_service.UploadFileVersionResponse
+	(*FileVersion)(nil),                // 13: file_service.FileVersion
+	(*ListFileVersionsRequest)(nil),    // 14: file_service.ListFileVersionsRequest
+	(*ListFileVersionsResponse)(nil),   // 15: file_service.ListFileVersionsResponse
+	(*RollbackFileRequest)(nil),        // 16: file_service.RollbackFileRequest
+	(*RollbackFileResponse)(nil),       // 17: file_service.RollbackFileResponse
+	(*TrashFileRequest)(nil),           // 18: file_service.TrashFileRequest
+	(*TrashFileResponse)(nil),          // 19: file_service.TrashFileResponse
+	(*RestoreFileRequest)(nil),         // 20: file_service.RestoreFileRequest
+	(*RestoreFileResponse)(nil),        // 21: file_service.RestoreFileResponse
+	(*ListTrashRequest)(nil),           // 22: file_service.ListTrashRequest
+	(*ListTrashResponse)(nil),          // 23: file_service.ListTrashResponse
+	(*AccessEntry)(nil),                // 24: file_service.AccessEntry
+	(*GrantAccessRequest)(nil),         // 25: file_service.GrantAccessRequest
+	(*GrantAccessResponse)(nil),        // 26: file_service.GrantAccessResponse
+	(*RevokeAccessRequest)(nil),        // 27: file_service.RevokeAccessRequest
+	(*RevokeAccessResponse)(nil),       // 28: file_service.RevokeAccessResponse
+	(*ListAccessRequest)(nil),          // 29: file_service.ListAccessRequest
+	(*ListAccessResponse)(nil),         // 30: file_service.ListAccessResponse
+	(*GetUsageRequest)(nil),            // 31: file_service.GetUsageRequest
+	(*GetUsageResponse)(nil),           // 32: file_service.GetUsageResponse
+	(*CreateDownloadLinkRequest)(nil),  // 33: file_service.CreateDownloadLinkRequest
+	(*CreateDownloadLinkResponse)(nil), // 34: file_service.CreateDownloadLinkResponse
+	(*CreateUploadLinkRequest)(nil),    // 35: file_service.CreateUploadLinkRequest
+	(*CreateUploadLinkResponse)(nil),   // 36: file_service.CreateUploadLinkResponse
+	(*DeleteFileRequest)(nil),          // 37: file_service.DeleteFileRequest
+	(*DeleteFileResponse)(nil),         // 38: file_service.DeleteFileResponse
+	(*GetFileInfoRequest)(nil),         // 39: file_service.GetFileInfoRequest
+	(*GetFileInfoResponse)(nil),        // 40: file_service.GetFileInfoResponse
+	(*InitiateUploadRequest)(nil),      // 41: file_service.InitiateUploadRequest
+	(*InitiateUploadResponse)(nil),     // 42: file_service.InitiateUploadResponse
+	(*UploadPartRequest)(nil),          // 43: file_service.UploadPartRequest
+	(*UploadPartResponse)(nil),         // 44: file_service.UploadPartResponse
+	(*GetUploadStatusRequest)(nil),     // 45: file_service.GetUploadStatusRequest
+	(*UploadedPart)(nil),               // 46: file_service.UploadedPart
+	(*GetUploadStatusResponse)(nil),    // 47: file_service.GetUploadStatusResponse
+	(*CompleteUploadRequest)(nil),      // 48: file_service.CompleteUploadRequest
+	(*CompleteUploadResponse)(nil),     // 49: file_service.CompleteUploadResponse
+	(*VerifyFileRequest)(nil),          // 50: file_service.VerifyFileRequest
+	(*VerifyFileResponse)(nil),         // 51: file_service.VerifyFileResponse
+	(*Tenant)(nil),                     // 52: file_service.Tenant
+	(*CreateTenantRequest)(nil),        // 53: file_service.CreateTenantRequest
+	(*CreateTenantResponse)(nil),       // 54: file_service.CreateTenantResponse
+	(*ListTenantsRequest)(nil),         // 55: file_service.ListTenantsRequest
+	(*ListTenantsResponse)(nil),        // 56: file_service.ListTenantsResponse
+	(*DisableTenantRequest)(nil),       // 57: file_service.DisableTenantRequest
+	(*DisableTenantResponse)(nil),      // 58: file_service.DisableTenantResponse
+	(*timestamppb.Timestamp)(nil),      // 59: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 60: google.protobuf.Duration
+}
+var file_file_service_file_service_proto_depIdxs = []int32{
+	59, // 0: file_service.UploadFileRequest.expiresAt:type_name -> google.protobuf.Timestamp
+	60, // 1: file_service.UploadFileRequest.ttl:type_name -> google.protobuf.Duration
+	59, // 2: file_service.ListFilesRequest.created_after:type_name -> google.protobuf.Timestamp
+	59, // 3: file_service.ListFilesRequest.created_before:type_name -> google.protobuf.Timestamp
+	0,  // 4: file_service.ListFilesRequest.sort_by:type_name -> file_service.SortField
+	1,  // 5: file_service.ListFilesRequest.sort_direction:type_name -> file_service.SortDirection
+	8,  // 6: file_service.ListFilesResponse.files:type_name -> file_service.FileInfo
+	59, // 7: file_service.FileInfo.createdAt:type_name -> google.protobuf.Timestamp
+	59, // 8: file_service.FileInfo.updatedAt:type_name -> google.protobuf.Timestamp
+	59, // 9: file_service.FileInfo.deletedAt:type_name -> google.protobuf.Timestamp
+	59, // 10: file_service.FileInfo.expiresAt:type_name -> google.protobuf.Timestamp
+	59, // 11: file_service.FileVersion.createdAt:type_name -> google.protobuf.Timestamp
+	13, // 12: file_service.ListFileVersionsResponse.versions:type_name -> file_service.FileVersion
+	8,  // 13: file_service.ListTrashResponse.files:type_name -> file_service.FileInfo
+	3,  // 14: file_service.AccessEntry.grantee_type:type_name -> file_service.GranteeType
+	2,  // 15: file_service.AccessEntry.permission:type_name -> file_service.Permission
+	59, // 16: file_service.AccessEntry.createdAt:type_name -> google.protobuf.Timestamp
+	3,  // 17: file_service.GrantAccessRequest.grantee_type:type_name -> file_service.GranteeType
+	2,  // 18: file_service.GrantAccessRequest.permissions:type_name -> file_service.Permission
+	3,  // 19: file_service.RevokeAccessRequest.grantee_type:type_name -> file_service.GranteeType
+	2,  // 20: file_service.RevokeAccessRequest.permissions:type_name -> file_service.Permission
+	24, // 21: file_service.ListAccessResponse.entries:type_name -> file_service.AccessEntry
+	60, // 22: file_service.CreateDownloadLinkRequest.ttl:type_name -> google.protobuf.Duration
+	59, // 23: file_service.CreateDownloadLinkResponse.expires_at:type_name -> google.protobuf.Timestamp
+	60, // 24: file_service.CreateUploadLinkRequest.ttl:type_name -> google.protobuf.Duration
+	59, // 25: file_service.CreateUploadLinkResponse.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 26: file_service.GetFileInfoResponse.file:type_name -> file_service.FileInfo
+	59, // 27: file_service.InitiateUploadResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	59, // 28: file_service.UploadedPart.uploadedAt:type_name -> google.protobuf.Timestamp
+	46, // 29: file_service.GetUploadStatusResponse.parts:type_name -> file_service.UploadedPart
+	59, // 30: file_service.GetUploadStatusResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	59, // 31: file_service.Tenant.createdAt:type_name -> google.protobuf.Timestamp
+	52, // 32: file_service.CreateTenantResponse.tenant:type_name -> file_service.Tenant
+	52, // 33: file_service.ListTenantsResponse.tenants:type_name -> file_service.Tenant
+	4,  // 34: file_service.FileService.UploadFile:input_type -> file_service.UploadFileRequest
+	6,  // 35: file_service.FileService.ListFiles:input_type -> file_service.ListFilesRequest
+	9,  // 36: file_service.FileService.GetFile:input_type -> file_service.GetFileRequest
+	37, // 37: file_service.FileService.DeleteFile:input_type -> file_service.DeleteFileRequest
+	39, // 38: file_service.FileService.GetFileInfo:input_type -> file_service.GetFileInfoRequest
+	41, // 39: file_service.FileService.InitiateUpload:input_type -> file_service.InitiateUploadRequest
+	43, // 40: file_service.FileService.UploadPart:input_type -> file_service.UploadPartRequest
+	45, // 41: file_service.FileService.GetUploadStatus:input_type -> file_service.GetUploadStatusRequest
+	48, // 42: file_service.FileService.CompleteUpload:input_type -> file_service.CompleteUploadRequest
+	50, // 43: file_service.FileService.VerifyFile:input_type -> file_service.VerifyFileRequest
+	18, // 44: file_service.FileService.TrashFile:input_type -> file_service.TrashFileRequest
+	20, // 45: file_service.FileService.RestoreFile:input_type -> file_service.RestoreFileRequest
+	22, // 46: file_service.FileService.ListTrash:input_type -> file_service.ListTrashRequest
+	11, // 47: file_service.FileService.UploadFileVersion:input_type -> file_service.UploadFileVersionRequest
+	14, // 48: file_service.FileService.ListFileVersions:input_type -> file_service.ListFileVersionsRequest
+	16, // 49: file_service.FileService.RollbackFile:input_type -> file_service.RollbackFileRequest
+	25, // 50: file_service.FileService.GrantAccess:input_type -> file_service.GrantAccessRequest
+	27, // 51: file_service.FileService.RevokeAccess:input_type -> file_service.RevokeAccessRequest
+	29, // 52: file_service.FileService.ListAccess:input_type -> file_service.ListAccessRequest
+	31, // 53: file_service.FileService.GetUsage:input_type -> file_service.GetUsageRequest
+	33, // 54: file_service.FileService.CreateDownloadLink:input_type -> file_service.CreateDownloadLinkRequest
+	35, // 55: file_service.FileService.CreateUploadLink:input_type -> file_service.CreateUploadLinkRequest
+	53, // 56: file_service.TenantService.CreateTenant:input_type -> file_service.CreateTenantRequest
+	55, // 57: file_service.TenantService.ListTenants:input_type -> file_service.ListTenantsRequest
+	57, // 58: file_service.TenantService.DisableTenant:input_type -> file_service.DisableTenantRequest
+	5,  // 59: file_service.FileService.UploadFile:output_type -> file_service.UploadFileResponse
+	7,  // 60: file_service.FileService.ListFiles:output_type -> file_service.ListFilesResponse
+	10, // 61: file_service.FileService.GetFile:output_type -> file_service.GetFileResponse
+	38, // 62: file_service.FileService.DeleteFile:output_type -> file_service.DeleteFileResponse
+	40, // 63: file_service.FileService.GetFileInfo:output_type -> file_service.GetFileInfoResponse
+	42, // 64: file_service.FileService.InitiateUpload:output_type -> file_service.InitiateUploadResponse
+	44, // 65: file_service.FileService.UploadPart:output_type -> file_service.UploadPartResponse
+	47, // 66: file_service.FileService.GetUploadStatus:output_type -> file_service.GetUploadStatusResponse
+	49, // 67: file_service.FileService.CompleteUpload:output_type -> file_service.CompleteUploadResponse
+	51, // 68: file_service.FileService.VerifyFile:output_type -> file_service.VerifyFileResponse
+	19, // 69: file_service.FileService.TrashFile:output_type -> file_service.TrashFileResponse
+	21, // 70: file_service.FileService.RestoreFile:output_type -> file_service.RestoreFileResponse
+	23, // 71: file_service.FileService.ListTrash:output_type -> file_service.ListTrashResponse
+	12, // 72: file_service.FileService.UploadFileVersion:output_type -> file_service.UploadFileVersionResponse
+	15, // 73: file_service.FileService.ListFileVersions:output_type -> file_service.ListFileVersionsResponse
+	17, // 74: file_service.FileService.RollbackFile:output_type -> file_service.RollbackFileResponse
+	26, // 75: file_service.FileService.GrantAccess:output_type -> file_service.GrantAccessResponse
+	28, // 76: file_service.FileService.RevokeAccess:output_type -> file_service.RevokeAccessResponse
+	30, // 77: file_service.FileService.ListAccess:output_type -> file_service.ListAccessResponse
+	32, // 78: file_service.FileService.GetUsage:output_type -> file_service.GetUsageResponse
+	34, // 79: file_service.FileService.CreateDownloadLink:output_type -> file_service.CreateDownloadLinkResponse
+	36, // 80: file_service.FileService.CreateUploadLink:output_type -> file_service.CreateUploadLinkResponse
+	54, // 81: file_service.TenantService.CreateTenant:output_type -> file_service.CreateTenantResponse
+	56, // 82: file_service.TenantService.ListTenants:output_type -> file_service.ListTenantsResponse
+	58, // 83: file_service.TenantService.DisableTenant:output_type -> file_service.DisableTenantResponse
+	59, // [59:84] is the sub-list for method output_type
+	34, // [34:59] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_file_service_file_service_proto_init() }
+func file_file_service_file_service_proto_init() {
+	if File_file_service_file_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_file_service_file_service_proto_rawDesc), len(file_file_service_file_service_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   55,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_file_service_file_service_proto_goTypes,
+		DependencyIndexes: file_file_service_file_service_proto_depIdxs,
+		EnumInfos:         file_file_service_file_service_proto_enumTypes,
+		MessageInfos:      file_file_service_file_service_proto_msgTypes,
+	}.Build()
+	File_file_service_file_service_proto = out.File
+	file_file_service_file_service_proto_goTypes = nil
+	file_file_service_file_service_proto_depIdxs = nil
+}